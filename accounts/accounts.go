@@ -19,4 +19,23 @@ const (
 	NiewydatkowanyDochod
 	RozniceKursowe
 	SprzedazNieewidencjonowana
+	Pozostale
+	Amortyzacja
+	Wynagrodzenia
+	Rozrachunki
+	UrzadSkarbowy
+	ZUS
+	RozliczenieVAT
+	Nalezny
+	Naliczony
+	Dotacje
+	SrodkiTrwale
+	Umorzenie
+	Bank
+	Kontrahenci
+	Fundusz
+	Odsetki
 )
+
+// Custom is the first account ID part reserved for user-defined accounts.
+const Custom types.AccountIDPart = 1000
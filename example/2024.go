@@ -305,6 +305,34 @@ var (
 			Nieodplatna,
 			"Miejsce na rejsie",
 		),
+		SprzedazPozycje(
+			Data(2025, 1, 8),
+			Dokument("FV/02/2025", Data(2025, 1, 8)),
+			Kontrahent("INVINI sp. z o. o.", "", ""),
+			Naleznosci(
+				Naleznosc(Data(2025, 1, 20), Kwota(150, 0, EUR)),
+			),
+			Niezaplacono(),
+			Pozycje(
+				Pozycja("Miejsce na rejsie", Kwota(100, 0, EUR), Ewidencjonowana, PrzychodOdplatny),
+				Pozycja("Wyżywienie", Kwota(30, 0, EUR), Nieewidencjonowana, PrzychodOdplatny),
+				Pozycja("Sprzedaż zbędnego wyposażenia", Kwota(20, 0, EUR), Nieewidencjonowana, PrzychodPozostaly),
+			),
+			"Rejs 2026/01",
+		),
+		ZakupZPodzialem(
+			Data(2025, 1, 8),
+			Dokument("FV/03/2024", Data(2025, 1, 5)),
+			Kontrahent("Stacja paliw sp. z o. o.", "", ""),
+			Kwota(100, 1, EUR),
+			Platnosci(Platnosc("WB/EUR/2025/01/26", Data(2025, 1, 7), 1, Kwota(100, 1, EUR))),
+			Podzial(
+				CzescKwotowa(NKUP, Nieodplatna, Kwota(10, 0, EUR)),
+				CzescProcentowa(KUP, Odplatna, Procent(66, 67)),
+				CzescProcentowa(KUP, Nieodplatna, Procent(33, 33)),
+			),
+			"Paliwo na rejs",
+		),
 	)
 )
 
@@ -317,6 +345,14 @@ var R2024 = Rok(
 		Waluty(
 			Waluta(Kwota(100, 0, EUR), Kwota(425, 0, PLN)),
 		),
+		SrodekTrwalyBO(
+			Dokument("FV/310/2023", Data(2023, 5, 10)),
+			Kwota(24000, 0, PLN),
+			KUP,
+			Nieodplatna,
+			"",
+			Amortyzacja("ŚT/0", "Przyczepa podłodziowa", "742", Liniowa, Procent(14, 0), Data(2023, 6, 1)),
+		),
 	),
 	// ========================================================
 	rejs2026HR01,
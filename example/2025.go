@@ -24,5 +24,172 @@ var R2025 = Rok(
 		Kontrahent("INVINI sp. z o. o.", "Felińskiego 2/17", ""),
 		Platnosc("WB/EUR/2025/01/01", Data(2025, 5, 3), 1, Kwota(500, 0, EUR)),
 	),
+	Darowizna(
+		Kontrahent("Fundacja Morska", "ul. Nabrzeże 3, 81-003 Gdynia", "5270000000"),
+		Platnosc("WB/PLN/2025/07/01", Data(2025, 7, 1), 1, Kwota(20000, 0, PLN)),
+	),
+	ZakupKSeF(
+		FakturaNaprawaZagla,
+		Platnosci(Platnosc("WB/PLN/2025/06/01", Data(2025, 6, 20), 1, Kwota(492, 0, PLN))),
+		Podzial(CzescProcentowa(KUP, Odplatna, Procent(100, 0))),
+		true,
+		"Naprawa żagla",
+	),
+	SprzedazPozycje(
+		Data(2025, 5, 3),
+		Faktura("FS", Data(2025, 5, 3)),
+		Kontrahent("INVINI sp. z o. o.", "Felińskiego 2/17", ""),
+		Naleznosci(Naleznosc(Data(2025, 5, 17), Kwota(246, 0, EUR))),
+		Platnosci(Platnosc("WB/EUR/2025/01/02", Data(2025, 5, 3), 2, Kwota(246, 0, EUR))),
+		Pozycje(
+			PozycjaVAT("Czarter jachtu", Kwota(200, 0, EUR), VAT23, Ewidencjonowana, PrzychodOdplatny),
+			PozycjaVAT("Sprzątanie jachtu", Kwota(46, 0, EUR), VAT23, Ewidencjonowana, PrzychodOdplatny),
+		),
+		"Czarter jachtu",
+	),
+	SrodekTrwaly(
+		Data(2025, 1, 8),
+		Dokument("FV/124/2025", Data(2025, 1, 8)),
+		Kontrahent("Sklep komputerowy sp. z o. o.", "", ""),
+		Kwota(12000, 0, PLN),
+		Platnosci(Platnosc("WB/PLN/2025/01/03", Data(2025, 2, 3), 3, Kwota(12000, 0, PLN))),
+		KUP,
+		Nieodplatna,
+		Amortyzacja("ŚT/2", "Serwer", "491", Degresywna, Procent(30, 0), Data(2025, 2, 1)),
+		"Serwer",
+	),
+	Projekt("Szkolenia żeglarskie",
+		SrodekTrwaly(
+			Data(2025, 1, 8),
+			Dokument("FV/123/2025", Data(2025, 1, 8)),
+			Kontrahent("Stocznia sp. z o. o.", "", ""),
+			Kwota(18000, 0, PLN),
+			Platnosci(Platnosc("WB/PLN/2025/01/02", Data(2025, 2, 3), 2, Kwota(18000, 0, PLN))),
+			KUP,
+			Odplatna,
+			Amortyzacja("ŚT/1", "Ponton z silnikiem", "743", Liniowa, Procent(20, 0), Data(2025, 2, 1)),
+			"Ponton z silnikiem",
+		),
+		Wyposazenie(
+			Zakup(
+				Data(2025, 5, 3),
+				Dokument("FV/55/2025", Data(2025, 5, 3)),
+				Kontrahent("Sklep żeglarski sp. z o. o.", "", ""),
+				Kwota(2400, 0, PLN),
+				Platnosci(Platnosc("WB/PLN/2025/05/01", Data(2025, 5, 3), 1, Kwota(2400, 0, PLN))),
+				KUP,
+				Odplatna,
+				"Kamizelki ratunkowe i żagiel",
+			),
+			Przedmiot("W/1", "Kamizelka ratunkowa", 10, Kwota(900, 0, PLN), "Magazyn", "Jan Kowalski"),
+			Przedmiot("W/2", "Żagiel", 1, Kwota(1500, 0, PLN), "Jacht", "Jan Kowalski"),
+		),
+		Dotacja(
+			Dokument("UD/1/2025", Data(2025, 6, 2)),
+			Kontrahent("Urząd Miasta", "", ""),
+			Platnosc("WB/PLN/2025/06/01", Data(2025, 6, 2), 1, Kwota(5000, 0, PLN)),
+			"Dotacja na szkolenia żeglarskie - transza 1",
+		),
+	),
+	Likwidacja(Data(2025, 9, 30), Dokument("PL/1/2025", Data(2025, 9, 30)), "W/2", "Zniszczenie w czasie sztormu"),
+	Umowa(
+		Data(2025, 7, 31),
+		Dokument("RU/1/2025", Data(2025, 7, 31)),
+		Wykonawca("Jan", "Kowalski", "85010112345",
+			AdresZamieszkania("POMORSKIE", "Gdynia", "Gdynia", "Morska", "1", "", "Gdynia", "81-001")),
+		UmowaZlecenie,
+		Kwota(3000, 0, PLN),
+		Ubezpieczenia(true, false, true, false),
+		Parametry2025,
+		KUP,
+		Odplatna,
+		Platnosci(Platnosc("WB/PLN/2025/08/01", Data(2025, 8, 5), 1, Kwota(2166, 60, PLN))),
+		"Prowadzenie jachtu w rejsie szkoleniowym",
+	),
+	Umowa(
+		Data(2025, 7, 31),
+		Dokument("RU/2/2025", Data(2025, 7, 31)),
+		Wykonawca("Anna", "Nowak", "90020254327",
+			AdresZamieszkania("POMORSKIE", "Gdańsk", "Gdańsk", "Portowa", "5", "", "Gdańsk", "80-001")),
+		UmowaODzielo,
+		Kwota(1000, 0, PLN),
+		BezUbezpieczen(),
+		Parametry2025,
+		KUP,
+		Nieodplatna,
+		Platnosci(Platnosc("WB/PLN/2025/08/02", Data(2025, 8, 5), 2, Kwota(904, 0, PLN))),
+		"Opracowanie materiałów szkoleniowych",
+	),
+	ZaplataPIT(
+		Data(2025, 8, 20),
+		Dokument("PIT/2025/07", Data(2025, 8, 20)),
+		Kontrahent("Urząd Skarbowy w Gdyni", "", ""),
+		Platnosci(Platnosc("WB/PLN/2025/08/03", Data(2025, 8, 20), 3, Kwota(352, 0, PLN))),
+		"Zaliczki na podatek za lipiec 2025",
+	),
+	ZaplataZUS(
+		Data(2025, 8, 15),
+		Dokument("DRA/2025/07", Data(2025, 8, 15)),
+		Kontrahent("Zakład Ubezpieczeń Społecznych", "", ""),
+		Platnosci(Platnosc("WB/PLN/2025/08/04", Data(2025, 8, 15), 4, Kwota(1115, 30, PLN))),
+		"Składki za lipiec 2025",
+	),
+	SprzedazPozycje(
+		Data(2025, 10, 6),
+		Dokument("FV/10/2025", Data(2025, 10, 6)),
+		Kontrahent("Szkoła Morska sp. z o. o.", "ul. Portowa 10, 81-001 Gdynia", "5860000000"),
+		Naleznosci(Naleznosc(Data(2025, 10, 20), Kwota(1230, 0, PLN))),
+		Platnosci(Platnosc("WB/PLN/2025/10/01", Data(2025, 10, 15), 1, Kwota(1230, 0, PLN))),
+		Pozycje(
+			PozycjaVAT("Szkolenie żeglarskie", Kwota(1230, 0, PLN), VAT23, Ewidencjonowana, PrzychodOdplatny),
+		),
+		"Szkolenie żeglarskie",
+	),
+	Korekta("FV/10/2025",
+		SprzedazPozycje(
+			Data(2025, 10, 20),
+			Dokument("FVK/1/2025", Data(2025, 10, 20)),
+			Kontrahent("Szkoła Morska sp. z o. o.", "ul. Portowa 10, 81-001 Gdynia", "5860000000"),
+			Naleznosci(Naleznosc(Data(2025, 10, 20), Ujemna(Kwota(123, 0, PLN)))),
+			Platnosci(Platnosc("WB/PLN/2025/10/03", Data(2025, 10, 22), 3, Ujemna(Kwota(123, 0, PLN)))),
+			Pozycje(
+				PozycjaVAT("Rabat za szkolenie żeglarskie", Ujemna(Kwota(123, 0, PLN)), VAT23, Ewidencjonowana,
+					PrzychodOdplatny),
+			),
+			"Rabat za szkolenie żeglarskie",
+		),
+	),
+	TerminPlatnosci(Data(2026, 1, 14),
+		Zakup(
+			Data(2025, 12, 15),
+			Dokument("FV/1200/2025", Data(2025, 12, 15)),
+			Kontrahent("Sklep żeglarski sp. z o. o.", "", ""),
+			Kwota(350, 0, PLN),
+			Niezaplacono(),
+			KUP,
+			Odplatna,
+			"Liny cumownicze",
+		),
+	),
+	Zakup(
+		Data(2025, 9, 1),
+		Dokument("FV/311/2025", Data(2025, 9, 1)),
+		Kontrahent("Stacja paliw sp. z o. o.", "", ""),
+		Kwota(120, 0, PLN),
+		Niezaplacono(),
+		KUP,
+		Odplatna,
+		"Paliwo do silnika",
+	),
+	ZakupVAT(
+		Data(2025, 10, 8),
+		Dokument("FV/987/2025", Data(2025, 10, 8)),
+		Kontrahent("Stocznia jachtowa sp. z o. o.", "", "5830000000"),
+		Kwota(615, 0, PLN),
+		Platnosci(Platnosc("WB/PLN/2025/10/02", Data(2025, 10, 8), 2, Kwota(615, 0, PLN))),
+		Podzial(CzescProcentowa(KUP, Odplatna, Procent(100, 0))),
+		StawkiVAT(KwotaVAT(VAT23, Kwota(615, 0, PLN), true)),
+		"Serwis silnika",
+	),
 	rejs2026HR01,
 )
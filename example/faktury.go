@@ -0,0 +1,8 @@
+package main
+
+import _ "embed" // Faktury otrzymane z KSeF.
+
+// FakturaNaprawaZagla to faktura zakupu otrzymana z KSeF.
+//
+//go:embed faktury/FZ-45-2025.xml
+var FakturaNaprawaZagla []byte
@@ -10,4 +10,5 @@ var KursyWalutowe = Kursy(
 	Kurs(EUR, Data(2025, 1, 2), 4, 4300),
 	Kurs(EUR, Data(2025, 1, 7), 4, 4800),
 	Kurs(EUR, Data(2025, 5, 2), 4, 4300),
+	Kurs(EUR, Data(2025, 12, 31), 4, 2200),
 )
@@ -1,7 +1,47 @@
 package main
 
-import . "github.com/outofforest/uepik/v2" //nolint:staticcheck
+import (
+	. "github.com/outofforest/uepik/v2" //nolint:staticcheck
+	"github.com/outofforest/uepik/v2/accounts"
+)
 
 func main() {
+	KodUrzeduSkarbowego(R2025, "1471")
+	DaneRejestrowe(R2025, "0000123456", true)
+	LimitDarczyncow(R2025, Kwota(15000, 0, PLN))
+	WyciagMT940(R2025, "WB/PLN/2025/08/01", WyciagSierpien)
+	LimitVAT(R2024, Kwota(200000, 0, PLN), Data(2024, 7, 1))
+	Budzet(R2025,
+		PozycjaBudzetu("Przychody", Konto(accounts.PiK, accounts.Przychody), Rocznie(Kwota(12000, 0, PLN))),
+		PozycjaBudzetu("Koszty", Konto(accounts.PiK, accounts.Koszty), Rocznie(Kwota(30000, 0, PLN))),
+		PozycjaBudzetu("Szkolenia żeglarskie - koszty",
+			Konto(accounts.PiK, accounts.Koszty),
+			Miesiecznie(
+				Kwota(0, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN),
+				Kwota(2700, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN),
+				Kwota(300, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN), Kwota(300, 0, PLN),
+			),
+			Wymiar(WymiarProjekt, "Szkolenia żeglarskie"),
+		),
+	)
+	KsiegaGlowna(R2025)
+	PelnaKsiegowosc(R2025)
+	RozrachunkiNaDzien(R2025, Data(2025, 10, 10))
+	WezwaniaDoZaplaty(R2025, Data(2025, 12, 31), Procent(13, 25))
+	OdsetkiUstawowe(R2025, OdsetkiHandlowe, true)
+	PodstawaZwolnieniaVAT(R2025, "art. 43 ust. 1 pkt 29 lit. a ustawy o VAT")
+	RachunekBankowy(R2025, PLN, "PL 61 1090 1014 0000 0712 1981 2874", "Santander Bank Polska")
+	RachunekBankowy(R2025, EUR, "PL 27 1140 2004 0000 3202 7823 4566", "mBank")
+
+	kosztyOperacyjne := Konto(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Operacyjne)
+	DodajKonto(R2025, kosztyOperacyjne,
+		NoweKonto(accounts.Custom, "01", "Koszty szkoleń", "", KontoKosztow, Zrodla(OperacjaZakup)))
+	DodajKonto(R2025, kosztyOperacyjne,
+		NoweKonto(accounts.Custom+1, "99", "Pozostałe koszty operacyjne", "", KontoKosztow, Zrodla(OperacjaZakup)))
+	RegulaKsiegowania(R2025, "Koszty szkoleń", Zrodla(OperacjaZakup), kosztyOperacyjne,
+		Konto(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Operacyjne, accounts.Custom),
+		Wymiar(WymiarProjekt, "Szkolenia żeglarskie"))
+	RegulaKsiegowania(R2025, "Pozostałe koszty", Zrodla(OperacjaZakup), kosztyOperacyjne,
+		Konto(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Operacyjne, accounts.Custom+1))
 	Raport(Teraz(), R2025, KursyWalutowe, R2024, R2025)
 }
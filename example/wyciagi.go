@@ -0,0 +1,8 @@
+package main
+
+import _ "embed" // Wyciągi bankowe w formacie MT940.
+
+// WyciagSierpien to wyciąg z rachunku PLN za sierpień 2025.
+//
+//go:embed wyciagi/WB-PLN-2025-08.sta
+var WyciagSierpien []byte
@@ -8,4 +8,4 @@ require (
 	github.com/shopspring/decimal v1.4.0
 )
 
-require golang.org/x/text v0.29.0 // indirect
+require golang.org/x/text v0.29.0
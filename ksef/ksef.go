@@ -0,0 +1,170 @@
+// Package ksef reads structured invoices in the FA format of KSeF.
+package ksef
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// Invoice is the purchase invoice received from KSeF.
+type Invoice struct {
+	Document          types.Document
+	Seller            types.Contractor
+	Amount            types.Denom
+	DueDate           time.Time
+	VATLines          []types.PurchaseVATLine
+	CorrectedDocument types.DocumentID
+}
+
+type faktura struct {
+	XMLName xml.Name `xml:"Faktura"`
+	Seller  podmiot  `xml:"Podmiot1"`
+	Fa      fa       `xml:"Fa"`
+}
+
+type podmiot struct {
+	TaxID    string `xml:"DaneIdentyfikacyjne>NIP"`
+	Name     string `xml:"DaneIdentyfikacyjne>Nazwa"`
+	Address1 string `xml:"Adres>AdresL1"`
+	Address2 string `xml:"Adres>AdresL2"`
+}
+
+type fa struct {
+	Currency  string   `xml:"KodWaluty"`
+	IssueDate string   `xml:"P_1"`
+	Number    string   `xml:"P_2"`
+	Total     string   `xml:"P_15"`
+	Kind      string   `xml:"RodzajFaktury"`
+	Corrected string   `xml:"DaneFaKorygowanej>NrFaKorygowanej"`
+	DueDates  []string `xml:"Platnosc>TerminPlatnosci>Termin"`
+	Fields    []field  `xml:",any"`
+}
+
+type field struct {
+	XMLName xml.Name
+	Value   string `xml:",chardata"`
+}
+
+// vatFields maps fields of FA containing net amounts to VAT rates and fields containing VAT amounts.
+var vatFields = []struct {
+	Net  string
+	VAT  string
+	Rate types.VATRate
+}{
+	{Net: "P_13_1", VAT: "P_14_1", Rate: types.VATRate23},
+	{Net: "P_13_2", VAT: "P_14_2", Rate: types.VATRate8},
+	{Net: "P_13_3", VAT: "P_14_3", Rate: types.VATRate5},
+	{Net: "P_13_6_1", Rate: types.VATRate0},
+	{Net: "P_13_6_2", Rate: types.VATRate0},
+	{Net: "P_13_6_3", Rate: types.VATRate0},
+	{Net: "P_13_7", Rate: types.VATRateExempt},
+	{Net: "P_13_8", Rate: types.VATRateNotSubject},
+	{Net: "P_13_9", Rate: types.VATRateNotSubject},
+	{Net: "P_13_10", Rate: types.VATRateNotSubject},
+}
+
+// unsupportedFields are fields of FA with amounts which cannot be represented by VAT rates.
+var unsupportedFields = []string{"P_13_4", "P_13_5", "P_13_11"}
+
+// Parse parses the invoice in FA(2) or FA(3) format. Dates are interpreted in the location.
+func Parse(data []byte, location *time.Location) (Invoice, error) {
+	var f faktura
+	if err := xml.Unmarshal(data, &f); err != nil {
+		return Invoice{}, errors.Wrap(err, "parsing invoice failed")
+	}
+
+	currency := types.CurrencySymbol(f.Fa.Currency)
+	issueDate, err := time.ParseInLocation(time.DateOnly, f.Fa.IssueDate, location)
+	if err != nil {
+		return Invoice{}, errors.Wrapf(err, "invalid issue date '%s'", f.Fa.IssueDate)
+	}
+	if f.Fa.Number == "" {
+		return Invoice{}, errors.New("invoice number is missing")
+	}
+	amount, err := types.ParseDenom(f.Fa.Total, currency)
+	if err != nil {
+		return Invoice{}, err
+	}
+
+	invoice := Invoice{
+		Document: types.Document{
+			ID:   types.DocumentID(f.Fa.Number),
+			Date: issueDate,
+		},
+		Seller: types.Contractor{
+			Name:    f.Seller.Name,
+			Address: strings.TrimSpace(f.Seller.Address1 + " " + f.Seller.Address2),
+			TaxID:   f.Seller.TaxID,
+		},
+		Amount: amount,
+	}
+	if len(f.Fa.DueDates) > 0 {
+		invoice.DueDate, err = time.ParseInLocation(time.DateOnly, f.Fa.DueDates[0], location)
+		if err != nil {
+			return Invoice{}, errors.Wrapf(err, "invalid due date '%s'", f.Fa.DueDates[0])
+		}
+	}
+	if f.Fa.Kind == "KOR" || f.Fa.Kind == "KOR_ZAL" || f.Fa.Kind == "KOR_ROZ" {
+		if f.Fa.Corrected == "" {
+			return Invoice{}, errors.New("corrected invoice number is missing")
+		}
+		invoice.CorrectedDocument = types.DocumentID(f.Fa.Corrected)
+	}
+
+	values := map[string]string{}
+	for _, fl := range f.Fa.Fields {
+		values[fl.XMLName.Local] = strings.TrimSpace(fl.Value)
+	}
+	for _, name := range unsupportedFields {
+		if _, exists := values[name]; exists {
+			return Invoice{}, errors.Errorf("field %s is not supported", name)
+		}
+	}
+
+	sum := types.NewDenom(currency)
+	for _, vf := range vatFields {
+		netValue, exists := values[vf.Net]
+		if !exists {
+			continue
+		}
+		gross, err := types.ParseDenom(netValue, currency)
+		if err != nil {
+			return Invoice{}, err
+		}
+		if vatValue, exists := values[vf.VAT]; vf.VAT != "" && exists {
+			vat, err := types.ParseDenom(vatValue, currency)
+			if err != nil {
+				return Invoice{}, err
+			}
+			gross = gross.Add(vat)
+		}
+		invoice.VATLines = addVATLine(invoice.VATLines, vf.Rate, gross)
+		sum = sum.Add(gross)
+	}
+	if len(invoice.VATLines) == 0 {
+		return Invoice{}, errors.New("invoice contains no amounts")
+	}
+	if sum.NEQ(amount) {
+		return Invoice{}, errors.Errorf("sum of amounts %s differs from total %s", sum, amount)
+	}
+
+	return invoice, nil
+}
+
+func addVATLine(lines []types.PurchaseVATLine, rate types.VATRate, gross types.Denom) []types.PurchaseVATLine {
+	for i, l := range lines {
+		if l.Rate == rate {
+			lines[i].Gross = l.Gross.Add(gross)
+			return lines
+		}
+	}
+	return append(lines, types.PurchaseVATLine{
+		Rate:  rate,
+		Gross: gross,
+	})
+}
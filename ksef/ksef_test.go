@@ -0,0 +1,271 @@
+package ksef
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func invoiceXML(seller, fa string) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<Faktura xmlns="http://crd.gov.pl/wzor/2025/06/25/13775/">
+	<Podmiot1>%s</Podmiot1>
+	<Fa>%s</Fa>
+</Faktura>`, seller, fa))
+}
+
+const defaultSeller = `
+	<DaneIdentyfikacyjne>
+		<NIP>5861234567</NIP>
+		<Nazwa>Żaglownia sp. z o. o.</Nazwa>
+	</DaneIdentyfikacyjne>
+	<Adres>
+		<KodKraju>PL</KodKraju>
+		<AdresL1>ul. Żeglarska 5</AdresL1>
+		<AdresL2>81-002 Gdynia</AdresL2>
+	</Adres>`
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		data      []byte
+		seller    types.Contractor
+		id        types.DocumentID
+		amount    string
+		dueDate   time.Time
+		vatLines  map[types.VATRate]string
+		corrected types.DocumentID
+	}{
+		{
+			name: "single rate",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/45/2025</P_2>
+				<P_13_1>400.00</P_13_1>
+				<P_14_1>92.00</P_14_1>
+				<P_15>492.00</P_15>
+				<RodzajFaktury>VAT</RodzajFaktury>
+				<Platnosc>
+					<TerminPlatnosci><Termin>2025-06-24</Termin></TerminPlatnosci>
+				</Platnosc>`),
+			seller: types.Contractor{
+				Name:    "Żaglownia sp. z o. o.",
+				Address: "ul. Żeglarska 5 81-002 Gdynia",
+				TaxID:   "5861234567",
+			},
+			id:       "FZ/45/2025",
+			amount:   "492.00",
+			dueDate:  time.Date(2025, time.June, 24, 0, 0, 0, 0, time.UTC),
+			vatLines: map[types.VATRate]string{types.VATRate23: "492.00"},
+		},
+		{
+			name: "escaped entities",
+			data: invoiceXML(`
+				<DaneIdentyfikacyjne>
+					<NIP>5861234567</NIP>
+					<Nazwa>Kowalski &amp; Syn &lt;Żagle&gt; &quot;Bryza&quot;</Nazwa>
+				</DaneIdentyfikacyjne>
+				<Adres>
+					<KodKraju>PL</KodKraju>
+					<AdresL1>ul. Portowa 1 &amp; 2</AdresL1>
+				</Adres>`, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/1&amp;2/2025</P_2>
+				<P_13_1>100.00</P_13_1>
+				<P_14_1>23.00</P_14_1>
+				<P_15>123.00</P_15>
+				<RodzajFaktury>VAT</RodzajFaktury>`),
+			seller: types.Contractor{
+				Name:    `Kowalski & Syn <Żagle> "Bryza"`,
+				Address: "ul. Portowa 1 & 2",
+				TaxID:   "5861234567",
+			},
+			id:       "FZ/1&2/2025",
+			amount:   "123.00",
+			vatLines: map[types.VATRate]string{types.VATRate23: "123.00"},
+		},
+		{
+			name: "many rates",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/46/2025</P_2>
+				<P_13_1>100.00</P_13_1>
+				<P_14_1>23.00</P_14_1>
+				<P_13_2>100.00</P_13_2>
+				<P_14_2>8.00</P_14_2>
+				<P_13_6_1>10.00</P_13_6_1>
+				<P_13_6_2>5.00</P_13_6_2>
+				<P_13_7>50.00</P_13_7>
+				<P_15>296.00</P_15>
+				<RodzajFaktury>VAT</RodzajFaktury>`),
+			seller: types.Contractor{
+				Name:    "Żaglownia sp. z o. o.",
+				Address: "ul. Żeglarska 5 81-002 Gdynia",
+				TaxID:   "5861234567",
+			},
+			id:     "FZ/46/2025",
+			amount: "296.00",
+			vatLines: map[types.VATRate]string{
+				types.VATRate23:     "123.00",
+				types.VATRate8:      "108.00",
+				types.VATRate0:      "15.00",
+				types.VATRateExempt: "50.00",
+			},
+		},
+		{
+			name: "correction",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-07-01</P_1>
+				<P_2>FZK/1/2025</P_2>
+				<P_13_1>-100.00</P_13_1>
+				<P_14_1>-23.00</P_14_1>
+				<P_15>-123.00</P_15>
+				<RodzajFaktury>KOR</RodzajFaktury>
+				<DaneFaKorygowanej>
+					<NrFaKorygowanej>FZ/45/2025</NrFaKorygowanej>
+				</DaneFaKorygowanej>`),
+			seller: types.Contractor{
+				Name:    "Żaglownia sp. z o. o.",
+				Address: "ul. Żeglarska 5 81-002 Gdynia",
+				TaxID:   "5861234567",
+			},
+			id:        "FZK/1/2025",
+			amount:    "-123.00",
+			vatLines:  map[types.VATRate]string{types.VATRate23: "-123.00"},
+			corrected: "FZ/45/2025",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			invoice, err := Parse(tt.data, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if invoice.Seller != tt.seller {
+				t.Errorf("seller: expected %+v, got %+v", tt.seller, invoice.Seller)
+			}
+			if invoice.Document.ID != tt.id {
+				t.Errorf("document ID: expected %s, got %s", tt.id, invoice.Document.ID)
+			}
+			if invoice.Amount.Amount.String() != tt.amount {
+				t.Errorf("amount: expected %s, got %s", tt.amount, invoice.Amount.Amount)
+			}
+			if !invoice.DueDate.Equal(tt.dueDate) {
+				t.Errorf("due date: expected %s, got %s", tt.dueDate, invoice.DueDate)
+			}
+			if invoice.CorrectedDocument != tt.corrected {
+				t.Errorf("corrected document: expected %s, got %s", tt.corrected, invoice.CorrectedDocument)
+			}
+			if len(invoice.VATLines) != len(tt.vatLines) {
+				t.Fatalf("expected %d VAT lines, got %d", len(tt.vatLines), len(invoice.VATLines))
+			}
+			for _, l := range invoice.VATLines {
+				if l.Gross.Amount.String() != tt.vatLines[l.Rate] {
+					t.Errorf("VAT rate %q: expected %s, got %s", l.Rate, tt.vatLines[l.Rate], l.Gross.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{
+			name:  "invalid XML",
+			data:  []byte(`<Faktura><Fa>Kowalski & Syn</Fa></Faktura>`),
+			error: "parsing invoice failed",
+		},
+		{
+			name: "invalid issue date",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>10.06.2025</P_1>
+				<P_2>FZ/45/2025</P_2>
+				<P_13_1>100.00</P_13_1>
+				<P_15>100.00</P_15>`),
+			error: "invalid issue date",
+		},
+		{
+			name: "missing number",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_13_1>100.00</P_13_1>
+				<P_15>100.00</P_15>`),
+			error: "invoice number is missing",
+		},
+		{
+			name: "missing corrected invoice",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZK/1/2025</P_2>
+				<P_13_1>-100.00</P_13_1>
+				<P_15>-100.00</P_15>
+				<RodzajFaktury>KOR</RodzajFaktury>`),
+			error: "corrected invoice number is missing",
+		},
+		{
+			name: "unsupported field",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/45/2025</P_2>
+				<P_13_4>100.00</P_13_4>
+				<P_15>100.00</P_15>`),
+			error: "field P_13_4 is not supported",
+		},
+		{
+			name: "no amounts",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/45/2025</P_2>
+				<P_15>100.00</P_15>`),
+			error: "invoice contains no amounts",
+		},
+		{
+			name: "total mismatch",
+			data: invoiceXML(defaultSeller, `
+				<KodWaluty>PLN</KodWaluty>
+				<P_1>2025-06-10</P_1>
+				<P_2>FZ/45/2025</P_2>
+				<P_13_1>100.00</P_13_1>
+				<P_14_1>23.00</P_14_1>
+				<P_15>100.00</P_15>`),
+			error: "differs from total",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tt.data, time.UTC)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.error) {
+				t.Fatalf("expected error containing %q, got %q", tt.error, err)
+			}
+		})
+	}
+}
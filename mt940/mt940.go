@@ -0,0 +1,224 @@
+// Package mt940 reads bank statements in the MT940 format.
+package mt940
+
+import (
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// Statement is the bank statement.
+type Statement struct {
+	Reference      string
+	Account        string
+	Number         string
+	OpeningBalance types.Denom
+	ClosingBalance types.Denom
+	Transactions   []types.BankTransaction
+}
+
+type field struct {
+	Tag   string
+	Value string
+}
+
+var (
+	tagRegexp         = regexp.MustCompile(`^:([0-9]{2}[A-Z]?):(.*)$`)
+	balanceRegexp     = regexp.MustCompile(`^([CD])([0-9]{6})([A-Z]{3})([0-9]+,[0-9]*)$`)
+	transactionRegexp = regexp.MustCompile(`^([0-9]{6})([0-9]{4})?(R?[CD])[A-Z]?([0-9]+,[0-9]*)`)
+	detailsRegexp     = regexp.MustCompile(`^[0-9]{3}([~^?])`)
+)
+
+// Parse parses statements stored in MT940 file. Files not encoded in UTF-8 are decoded as Windows-1250, used by
+// polish banks. Dates are interpreted in the location.
+func Parse(data []byte, location *time.Location) ([]Statement, error) {
+	if !utf8.Valid(data) {
+		var err error
+		data, err = charmap.Windows1250.NewDecoder().Bytes(data)
+		if err != nil {
+			return nil, errors.Wrap(err, "decoding statement failed")
+		}
+	}
+
+	statements := []Statement{}
+	fields := []field{}
+	for _, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		line = strings.TrimRight(line, " \r")
+		switch {
+		case line == "" || strings.HasPrefix(line, "{"):
+		case line == "-" || line == "-}":
+			if len(fields) > 0 {
+				s, err := parseStatement(fields, location)
+				if err != nil {
+					return nil, err
+				}
+				statements = append(statements, s)
+				fields = []field{}
+			}
+		case tagRegexp.MatchString(line):
+			m := tagRegexp.FindStringSubmatch(line)
+			fields = append(fields, field{Tag: m[1], Value: m[2]})
+		case len(fields) > 0:
+			fields[len(fields)-1].Value += "\n" + line
+		default:
+			return nil, errors.Errorf("unexpected line '%s'", line)
+		}
+	}
+	if len(fields) > 0 {
+		s, err := parseStatement(fields, location)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, s)
+	}
+	if len(statements) == 0 {
+		return nil, errors.New("no statements found")
+	}
+	return statements, nil
+}
+
+func parseStatement(fields []field, location *time.Location) (Statement, error) {
+	var s Statement
+	var balance types.Denom
+	var opened, closed bool
+	for _, f := range fields {
+		switch f.Tag {
+		case "20":
+			s.Reference = f.Value
+		case "25":
+			s.Account = strings.TrimPrefix(f.Value, "/")
+		case "28C":
+			s.Number = f.Value
+		case "60F", "60M":
+			var err error
+			s.OpeningBalance, err = parseBalance(f.Value)
+			if err != nil {
+				return Statement{}, err
+			}
+			balance = s.OpeningBalance
+			opened = true
+		case "61":
+			if !opened {
+				return Statement{}, errors.New("transaction precedes opening balance")
+			}
+			t, err := parseTransaction(f.Value, balance.Currency, location)
+			if err != nil {
+				return Statement{}, err
+			}
+			balance = balance.Add(t.Amount)
+			t.Balance = balance
+			s.Transactions = append(s.Transactions, t)
+		case "86":
+			if len(s.Transactions) == 0 {
+				continue
+			}
+			t := &s.Transactions[len(s.Transactions)-1]
+			t.Title, t.Counterparty, t.CounterpartyAccount = parseDetails(f.Value)
+		case "62F", "62M":
+			var err error
+			s.ClosingBalance, err = parseBalance(f.Value)
+			if err != nil {
+				return Statement{}, err
+			}
+			closed = true
+		}
+	}
+
+	if !opened || !closed {
+		return Statement{}, errors.Errorf("statement '%s' has no opening or closing balance", s.Reference)
+	}
+	if balance.Currency != s.ClosingBalance.Currency || balance.NEQ(s.ClosingBalance) {
+		return Statement{}, errors.Errorf("closing balance %s of statement '%s' does not match transactions, "+
+			"expected %s", s.ClosingBalance, s.Reference, balance)
+	}
+	return s, nil
+}
+
+func parseBalance(value string) (types.Denom, error) {
+	m := balanceRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return types.Denom{}, errors.Errorf("invalid balance '%s'", value)
+	}
+	amount, err := parseAmount(m[4], types.CurrencySymbol(m[3]))
+	if err != nil {
+		return types.Denom{}, err
+	}
+	if m[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, nil
+}
+
+func parseTransaction(value string, currency types.CurrencySymbol, location *time.Location) (
+	types.BankTransaction, error,
+) {
+	m := transactionRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return types.BankTransaction{}, errors.Errorf("invalid transaction '%s'", value)
+	}
+	date, err := time.ParseInLocation("060102", m[1], location)
+	if err != nil {
+		return types.BankTransaction{}, errors.Wrapf(err, "invalid transaction date '%s'", m[1])
+	}
+	amount, err := parseAmount(m[4], currency)
+	if err != nil {
+		return types.BankTransaction{}, err
+	}
+	// Debit and reversal of credit decrease the balance.
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+	return types.BankTransaction{
+		Date:   date,
+		Amount: amount,
+	}, nil
+}
+
+func parseAmount(amount string, currency types.CurrencySymbol) (types.Denom, error) {
+	amount = strings.Replace(amount, ",", ".", 1)
+	if strings.HasSuffix(amount, ".") {
+		amount += "0"
+	}
+	return types.ParseDenom(amount, currency)
+}
+
+// parseDetails returns title, counterparty and its account from the information field. Structured field used by
+// polish banks stores title in subfields 20-25, counterparty in 32-33 (or 27-29) and account in 38 (or 30-31).
+func parseDetails(value string) (string, types.Contractor, string) {
+	m := detailsRegexp.FindStringSubmatch(value)
+	if m == nil {
+		return strings.Join(strings.Fields(value), " "), types.Contractor{}, ""
+	}
+
+	subfields := map[string]string{}
+	for _, sf := range strings.Split(strings.ReplaceAll(value, "\n", ""), m[1])[1:] {
+		if len(sf) < 2 {
+			continue
+		}
+		subfields[sf[:2]] += sf[2:]
+	}
+	// Texts are wrapped at the fixed width, so subfields are concatenated without separators.
+	join := func(codes ...string) string {
+		var text string
+		for _, c := range codes {
+			text += subfields[c]
+		}
+		return strings.Join(strings.Fields(text), " ")
+	}
+
+	counterparty := types.Contractor{Name: join("32", "33")}
+	if counterparty.Name == "" {
+		counterparty = types.Contractor{Name: join("27", "28"), Address: join("29")}
+	}
+	account := join("38")
+	if account == "" {
+		account = join("30", "31")
+	}
+	return join("20", "21", "22", "23", "24", "25"), counterparty, strings.ReplaceAll(account, " ", "")
+}
@@ -0,0 +1,426 @@
+package mt940
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func lines(l ...string) []byte {
+	return []byte(strings.Join(l, "\r\n"))
+}
+
+func windows1250(data []byte) []byte {
+	encoded, err := charmap.Windows1250.NewEncoder().Bytes(data)
+	if err != nil {
+		panic(err)
+	}
+	return encoded
+}
+
+type expectedTransaction struct {
+	Date                time.Time
+	Amount              string
+	Balance             string
+	Title               string
+	Counterparty        types.Contractor
+	CounterpartyAccount string
+}
+
+type expectedStatement struct {
+	Reference      string
+	Account        string
+	Number         string
+	OpeningBalance string
+	ClosingBalance string
+	Transactions   []expectedTransaction
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		data       []byte
+		statements []expectedStatement
+	}{
+		{
+			name: "structured details",
+			data: lines(
+				"{1:F01BPKOPLPWAXXX0000000000}{2:I940BPKOPLPWXXXXN}{4:",
+				":20:WB/1",
+				":25:/PL61109010140000071219812874",
+				":28C:00001/1",
+				":60F:C250731PLN1000,00",
+				":61:2508050805D200,50NTRFNONREF//1",
+				":86:020~00TRF~20Wynagrodzenie RU/1/2025~30109010140~310000071219812875~32Jan Kowalski~33"+
+					"~38PL27109010140000071219812875",
+				":61:2508250825C500,NTRFNONREF//2",
+				":86:051~00TRF~20Darowizna~27Piotr Zieliński~29ul. Morska 1~30109010140~310000071219812879",
+				":62F:C250825PLN1299,50",
+				"-}",
+			),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/1",
+					Account:        "PL61109010140000071219812874",
+					Number:         "00001/1",
+					OpeningBalance: "1000.00",
+					ClosingBalance: "1299.50",
+					Transactions: []expectedTransaction{
+						{
+							Date:                time.Date(2025, time.August, 5, 0, 0, 0, 0, time.UTC),
+							Amount:              "-200.50",
+							Balance:             "799.50",
+							Title:               "Wynagrodzenie RU/1/2025",
+							Counterparty:        types.Contractor{Name: "Jan Kowalski"},
+							CounterpartyAccount: "PL27109010140000071219812875",
+						},
+						{
+							Date:                time.Date(2025, time.August, 25, 0, 0, 0, 0, time.UTC),
+							Amount:              "500.00",
+							Balance:             "1299.50",
+							Title:               "Darowizna",
+							Counterparty:        types.Contractor{Name: "Piotr Zieliński", Address: "ul. Morska 1"},
+							CounterpartyAccount: "1090101400000071219812879",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "multi-line details",
+			data: lines(
+				":20:WB/2",
+				":25:/PL61109010140000071219812874",
+				":60F:C250731PLN100,00",
+				":61:2508150815D15,30NTRFNONREF//1",
+				":86:020^00TRF^20Składki DRA 07/2025 za pracow",
+				"^21ników^32Zakład Ubezpieczeń Społeczn",
+				"^33ych^38PL70 1090 1014 0000 0712 1981 2877",
+				":62F:C250815PLN84,70",
+				"-",
+			),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/2",
+					Account:        "PL61109010140000071219812874",
+					OpeningBalance: "100.00",
+					ClosingBalance: "84.70",
+					Transactions: []expectedTransaction{
+						{
+							Date:                time.Date(2025, time.August, 15, 0, 0, 0, 0, time.UTC),
+							Amount:              "-15.30",
+							Balance:             "84.70",
+							Title:               "Składki DRA 07/2025 za pracowników",
+							Counterparty:        types.Contractor{Name: "Zakład Ubezpieczeń Społecznych"},
+							CounterpartyAccount: "PL70109010140000071219812877",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "unstructured details",
+			data: lines(
+				":20:WB/3",
+				":25:/PL61109010140000071219812874",
+				":60F:C250731PLN100,00",
+				":61:2508290829D12,50NMSCNONREF//1",
+				":86:Opłata za prowadzenie",
+				"  rachunku 08/2025",
+				":62F:C250829PLN87,50",
+			),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/3",
+					Account:        "PL61109010140000071219812874",
+					OpeningBalance: "100.00",
+					ClosingBalance: "87.50",
+					Transactions: []expectedTransaction{
+						{
+							Date:    time.Date(2025, time.August, 29, 0, 0, 0, 0, time.UTC),
+							Amount:  "-12.50",
+							Balance: "87.50",
+							Title:   "Opłata za prowadzenie rachunku 08/2025",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reversals",
+			data: lines(
+				":20:WB/4",
+				":25:/PL61109010140000071219812874",
+				":60F:C250731PLN100,00",
+				":61:2508010801C50,00NTRFNONREF//1",
+				":61:2508020802RC50,00NTRFNONREF//2",
+				":61:2508030803D30,00NTRFNONREF//3",
+				":61:2508040804RD30,00NTRFNONREF//4",
+				":62F:C250804PLN100,00",
+			),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/4",
+					Account:        "PL61109010140000071219812874",
+					OpeningBalance: "100.00",
+					ClosingBalance: "100.00",
+					Transactions: []expectedTransaction{
+						{
+							Date:    time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC),
+							Amount:  "50.00",
+							Balance: "150.00",
+						},
+						{
+							Date:    time.Date(2025, time.August, 2, 0, 0, 0, 0, time.UTC),
+							Amount:  "-50.00",
+							Balance: "100.00",
+						},
+						{
+							Date:    time.Date(2025, time.August, 3, 0, 0, 0, 0, time.UTC),
+							Amount:  "-30.00",
+							Balance: "70.00",
+						},
+						{
+							Date:    time.Date(2025, time.August, 4, 0, 0, 0, 0, time.UTC),
+							Amount:  "30.00",
+							Balance: "100.00",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "windows-1250",
+			data: windows1250(lines(
+				":20:WB/5",
+				":25:/PL61109010140000071219812874",
+				":60M:D250731PLN10,00",
+				":61:2508050805C25,00NTRFNONREF//1",
+				":86:051~00TRF~20Zwrot za żagiel~32Łukasz Żółć",
+				":62M:C250805PLN15,00",
+			)),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/5",
+					Account:        "PL61109010140000071219812874",
+					OpeningBalance: "-10.00",
+					ClosingBalance: "15.00",
+					Transactions: []expectedTransaction{
+						{
+							Date:         time.Date(2025, time.August, 5, 0, 0, 0, 0, time.UTC),
+							Amount:       "25.00",
+							Balance:      "15.00",
+							Title:        "Zwrot za żagiel",
+							Counterparty: types.Contractor{Name: "Łukasz Żółć"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "multiple statements",
+			data: lines(
+				"{1:F01BPKOPLPWAXXX0000000000}{2:I940BPKOPLPWXXXXN}{4:",
+				":20:WB/6",
+				":25:/PL61109010140000071219812874",
+				":28C:00001/1",
+				":60F:C250731PLN100,00",
+				":62F:C250731PLN100,00",
+				"-}",
+				"{1:F01BPKOPLPWAXXX0000000000}{2:I940BPKOPLPWXXXXN}{4:",
+				":20:WB/7",
+				":25:/PL61109010140000071219812874",
+				":28C:00002/1",
+				":60F:C250801PLN100,00",
+				":61:2508010801D100,00NTRFNONREF//1",
+				":62F:C250801PLN0,00",
+				"-}",
+			),
+			statements: []expectedStatement{
+				{
+					Reference:      "WB/6",
+					Account:        "PL61109010140000071219812874",
+					Number:         "00001/1",
+					OpeningBalance: "100.00",
+					ClosingBalance: "100.00",
+				},
+				{
+					Reference:      "WB/7",
+					Account:        "PL61109010140000071219812874",
+					Number:         "00002/1",
+					OpeningBalance: "100.00",
+					ClosingBalance: "0.00",
+					Transactions: []expectedTransaction{
+						{
+							Date:    time.Date(2025, time.August, 1, 0, 0, 0, 0, time.UTC),
+							Amount:  "-100.00",
+							Balance: "0.00",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statements, err := Parse(tt.data, time.UTC)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(statements) != len(tt.statements) {
+				t.Fatalf("expected %d statements, got %d", len(tt.statements), len(statements))
+			}
+			for i, s := range statements {
+				assertStatement(t, tt.statements[i], s)
+			}
+		})
+	}
+}
+
+func assertStatement(t *testing.T, expected expectedStatement, s Statement) {
+	t.Helper()
+
+	if s.Reference != expected.Reference || s.Account != expected.Account || s.Number != expected.Number {
+		t.Errorf("statement: expected %s %s %s, got %s %s %s", expected.Reference, expected.Account,
+			expected.Number, s.Reference, s.Account, s.Number)
+	}
+	if s.OpeningBalance.Amount.String() != expected.OpeningBalance {
+		t.Errorf("opening balance: expected %s, got %s", expected.OpeningBalance, s.OpeningBalance.Amount)
+	}
+	if s.ClosingBalance.Amount.String() != expected.ClosingBalance {
+		t.Errorf("closing balance: expected %s, got %s", expected.ClosingBalance, s.ClosingBalance.Amount)
+	}
+	if len(s.Transactions) != len(expected.Transactions) {
+		t.Fatalf("expected %d transactions, got %d", len(expected.Transactions), len(s.Transactions))
+	}
+	for i, tr := range s.Transactions {
+		e := expected.Transactions[i]
+		if !tr.Date.Equal(e.Date) {
+			t.Errorf("transaction %d date: expected %s, got %s", i, e.Date, tr.Date)
+		}
+		if tr.Amount.Amount.String() != e.Amount || tr.Balance.Amount.String() != e.Balance {
+			t.Errorf("transaction %d: expected amount %s and balance %s, got %s and %s", i, e.Amount, e.Balance,
+				tr.Amount.Amount, tr.Balance.Amount)
+		}
+		if tr.Title != e.Title {
+			t.Errorf("transaction %d title: expected %q, got %q", i, e.Title, tr.Title)
+		}
+		if tr.Counterparty != e.Counterparty {
+			t.Errorf("transaction %d counterparty: expected %+v, got %+v", i, e.Counterparty, tr.Counterparty)
+		}
+		if tr.CounterpartyAccount != e.CounterpartyAccount {
+			t.Errorf("transaction %d counterparty account: expected %s, got %s", i, e.CounterpartyAccount,
+				tr.CounterpartyAccount)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{
+			name:  "no statements",
+			data:  lines("{1:F01BPKOPLPWAXXX0000000000}{2:I940BPKOPLPWXXXXN}{4:", "-}"),
+			error: "no statements found",
+		},
+		{
+			name:  "unexpected line",
+			data:  lines("WB/1", ":20:WB/1"),
+			error: "unexpected line 'WB/1'",
+		},
+		{
+			name: "closing balance mismatch",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100,00",
+				":61:2508010801D10,00NTRFNONREF//1",
+				":62F:C250801PLN100,00",
+			),
+			error: "does not match transactions",
+		},
+		{
+			name: "currency mismatch",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100,00",
+				":62F:C250801EUR100,00",
+			),
+			error: "does not match transactions",
+		},
+		{
+			name: "missing closing balance",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100,00",
+			),
+			error: "has no opening or closing balance",
+		},
+		{
+			name: "transaction before opening balance",
+			data: lines(
+				":20:WB/1",
+				":61:2508010801D10,00NTRFNONREF//1",
+				":60F:C250731PLN100,00",
+				":62F:C250801PLN90,00",
+			),
+			error: "transaction precedes opening balance",
+		},
+		{
+			name: "invalid balance",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100.00",
+				":62F:C250801PLN100,00",
+			),
+			error: "invalid balance",
+		},
+		{
+			name: "invalid transaction",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100,00",
+				":61:250801X10,00NTRFNONREF//1",
+				":62F:C250801PLN100,00",
+			),
+			error: "invalid transaction",
+		},
+		{
+			name: "invalid transaction date",
+			data: lines(
+				":20:WB/1",
+				":60F:C250731PLN100,00",
+				":61:2513010801D10,00NTRFNONREF//1",
+				":62F:C250801PLN90,00",
+			),
+			error: "invalid transaction date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Parse(tt.data, time.UTC)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.error) {
+				t.Fatalf("expected error containing %q, got %q", tt.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package documents
+
+import (
+	_ "embed"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed balancecheck.tmpl.xml
+	balanceCheckTmpl     string
+	balanceCheckTemplate = template.Must(template.New("balanceCheck").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(balanceCheckTmpl))
+)
+
+// BalanceCheckReport lists entries violating equality of debits and credits.
+type BalanceCheckReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           uint64
+	Records        []BalanceCheckRecord
+}
+
+// BalanceCheckRecord is the unbalanced entry.
+type BalanceCheckRecord struct {
+	Data       types.EntryDataSource
+	Debit      types.Denom
+	Credit     types.Denom
+	Difference types.Denom
+}
+
+// GenerateBalanceCheckReport generates the report of unbalanced entries. Nothing is generated if balanced mode is
+// disabled.
+func GenerateBalanceCheckReport(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+) []types.ReportDocument {
+	if _, ok := coa.BalancedMode(); !ok {
+		return nil
+	}
+
+	report := &BalanceCheckReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Year:           uint64(period.Start.Year()),
+	}
+	for _, v := range coa.Violations() {
+		report.Records = append(report.Records, BalanceCheckRecord{
+			Data:       v.Data,
+			Debit:      v.Debit,
+			Credit:     v.Credit,
+			Difference: v.Debit.Sub(v.Credit),
+		})
+	}
+
+	return []types.ReportDocument{{
+		Template: balanceCheckTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Kontrola bilansowania",
+			LockedRows: 4,
+		},
+	}}
+}
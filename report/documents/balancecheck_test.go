@@ -0,0 +1,83 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func TestBalanceCheckReport(t *testing.T) {
+	t.Parallel()
+
+	type violation struct {
+		debit      string
+		credit     string
+		difference string
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		violations []violation
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:       "enabled",
+			enabled:    true,
+			violations: []violation{{debit: "100.00", credit: "70.00", difference: "30.00"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := types.NewChartOfAccounts(firstQuarter,
+				types.NewAccount(1, types.NewAccountLabel("130", "Bank", ""), types.Assets, types.AllValid()),
+				types.NewAccount(2, types.NewAccountLabel("800", "Fundusz", ""), types.Liabilities,
+					types.AllValid()),
+				types.NewAccount(3, types.NewAccountLabel("400", "Koszty", ""), types.Costs, types.AllValid()),
+			)
+			if tt.enabled {
+				coa.EnableBalancedMode(types.BalancedMode{
+					Bank:         bankAccount,
+					Settlements:  fundAccount,
+					Fund:         fundAccount,
+					FixedAssets:  bankAccount,
+					Depreciation: fundAccount,
+				})
+			}
+			book(coa, day(2025, time.January, 10),
+				types.NewEntryRecord(costsAccount, types.DebitBalance(pln("50.00"))),
+				types.NewEntryRecord(bankAccount, types.CreditBalance(pln("50.00"))),
+			)
+			book(coa, day(2025, time.February, 10),
+				types.NewEntryRecord(costsAccount, types.DebitBalance(pln("100.00"))),
+				types.NewEntryRecord(bankAccount, types.CreditBalance(pln("70.00"))),
+			)
+
+			docs := documents.GenerateBalanceCheckReport(firstQuarter, coa, "", "")
+			if !tt.enabled {
+				if len(docs) != 0 {
+					t.Fatalf("expected no report, got %d", len(docs))
+				}
+				return
+			}
+
+			report := docs[0].Data.(*documents.BalanceCheckReport)
+			if len(report.Records) != len(tt.violations) {
+				t.Fatalf("expected %d records, got %d", len(tt.violations), len(report.Records))
+			}
+			for i, v := range tt.violations {
+				r := report.Records[i]
+				assertDenom(t, "debit", v.debit, r.Debit)
+				assertDenom(t, "credit", v.credit, r.Credit)
+				assertDenom(t, "difference", v.difference, r.Difference)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed bankstatement.tmpl.xml
+	bankStatementTmpl     string
+	bankStatementTemplate = template.Must(template.New("bankStatement").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(bankStatementTmpl))
+)
+
+// Statuses of the bank statement reconciliation.
+const (
+	bankMatchAgreed             = "Zgodna"
+	bankMatchAmountMismatch     = "Niezgodna kwota"
+	bankMatchDateMismatch       = "Niezgodna data"
+	bankMatchOtherReference     = "Płatność pod innym numerem"
+	bankMatchMissingPayment     = "Brak płatności"
+	bankMatchMissingOnStatement = "Brak na wyciągu"
+)
+
+// BankStatementReport reconciles bank statements with payments.
+type BankStatementReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           int
+	Records        []BankStatementRecord
+}
+
+// BankStatementRecord is the bank statement transaction and payments matched to it.
+type BankStatementRecord struct {
+	DocumentID   types.DocumentID
+	Index        uint64
+	Date         time.Time
+	Counterparty types.Contractor
+	Title        string
+	Amount       types.Denom
+	Balance      types.Denom
+	HasBalance   bool
+	Status       string
+	Payment      string
+	PaidDocument string
+	Proposal     string
+}
+
+type bankPayment struct {
+	DocumentID    types.DocumentID
+	Index         uint64
+	Date          time.Time
+	Contractor    types.Contractor
+	Amount        types.Denom
+	PaidDocuments []string
+	Matched       bool
+}
+
+// GenerateBankStatementReport matches imported bank transactions to payments of operations by statement and
+// position on it, then by amount and date. Operations are proposed for transactions without payments.
+func GenerateBankStatementReport(
+	period types.Period,
+	transactions []types.BankTransaction,
+	operations []types.Operation,
+	companyName, companyAddress string,
+) []types.ReportDocument {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	return []types.ReportDocument{{
+		Template: bankStatementTemplate,
+		Data: &BankStatementReport{
+			CompanyName:    companyName,
+			CompanyAddress: companyAddress,
+			Year:           period.Start.Year(),
+			Records:        MatchBankTransactions(transactions, operations),
+		},
+		Config: types.SheetConfig{
+			Name:       "Wyciągi",
+			LockedRows: 4,
+		},
+	}}
+}
+
+// MatchBankTransactions matches bank transactions to payments of operations. Payments booked on imported statements
+// but not found on them are reported too.
+func MatchBankTransactions(
+	transactions []types.BankTransaction,
+	operations []types.Operation,
+) []BankStatementRecord {
+	payments := bankPayments(operations)
+
+	records := make([]BankStatementRecord, 0, len(transactions))
+	for _, t := range transactions {
+		r := BankStatementRecord{
+			DocumentID:   t.DocumentID,
+			Index:        t.Index,
+			Date:         t.Date,
+			Counterparty: t.Counterparty,
+			Title:        t.Title,
+			Amount:       t.Amount,
+			Balance:      t.Balance,
+			HasBalance:   true,
+		}
+
+		if p := findBankPayment(payments, func(p *bankPayment) bool {
+			return p.DocumentID == t.DocumentID && p.Index == t.Index
+		}); p != nil {
+			p.Matched = true
+			r.Status = bankMatchAgreed
+			switch {
+			case p.Amount.Currency != t.Amount.Currency || p.Amount.NEQ(t.Amount):
+				r.Status = bankMatchAmountMismatch
+			case !truncateDay(p.Date).Equal(truncateDay(t.Date)):
+				r.Status = bankMatchDateMismatch
+			}
+			r.Payment = paymentReference(p)
+			r.PaidDocument = strings.Join(p.PaidDocuments, ", ")
+		} else if p := findBankPayment(payments, func(p *bankPayment) bool {
+			return p.Amount.Currency == t.Amount.Currency && p.Amount.EQ(t.Amount) &&
+				truncateDay(p.Date).Equal(truncateDay(t.Date))
+		}); p != nil {
+			p.Matched = true
+			r.Status = bankMatchOtherReference
+			r.Payment = paymentReference(p)
+			r.PaidDocument = strings.Join(p.PaidDocuments, ", ")
+		} else {
+			r.Status = bankMatchMissingPayment
+			r.Proposal = proposeOperation(t)
+		}
+		records = append(records, r)
+	}
+
+	statements := map[types.DocumentID]bool{}
+	for _, t := range transactions {
+		statements[t.DocumentID] = true
+	}
+	for _, p := range payments {
+		if p.Matched || !statements[p.DocumentID] {
+			continue
+		}
+		records = append(records, BankStatementRecord{
+			DocumentID:   p.DocumentID,
+			Index:        p.Index,
+			Date:         p.Date,
+			Counterparty: p.Contractor,
+			Amount:       p.Amount,
+			Status:       bankMatchMissingOnStatement,
+			Payment:      paymentReference(p),
+			PaidDocument: strings.Join(p.PaidDocuments, ", "),
+		})
+	}
+
+	return records
+}
+
+// bankPayments groups bank records of operations by statement and position on it, because single transfer might
+// pay many documents.
+func bankPayments(operations []types.Operation) []*bankPayment {
+	payments := []*bankPayment{}
+	index := map[types.DocumentID]map[uint64]*bankPayment{}
+	for _, op := range operations {
+		for _, br := range op.BankRecords() {
+			if index[br.Document] == nil {
+				index[br.Document] = map[uint64]*bankPayment{}
+			}
+			p := index[br.Document][br.Index]
+			if p == nil {
+				p = &bankPayment{
+					DocumentID: br.Document,
+					Index:      br.Index,
+					Date:       br.Date,
+					Contractor: br.Contractor,
+					Amount:     types.NewDenom(br.OriginalAmount.Currency),
+				}
+				index[br.Document][br.Index] = p
+				payments = append(payments, p)
+			}
+			if p.Amount.Currency == br.OriginalAmount.Currency {
+				p.Amount = p.Amount.Add(br.OriginalAmount)
+			}
+			if br.PaidDocument.ID != "" && br.PaidDocument.ID != br.Document {
+				p.PaidDocuments = append(p.PaidDocuments, string(br.PaidDocument.ID))
+			}
+		}
+	}
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].Date.Before(payments[j].Date)
+	})
+	return payments
+}
+
+func findBankPayment(payments []*bankPayment, match func(p *bankPayment) bool) *bankPayment {
+	for _, p := range payments {
+		if !p.Matched && match(p) {
+			return p
+		}
+	}
+	return nil
+}
+
+func paymentReference(p *bankPayment) string {
+	return fmt.Sprintf("%s/%d", p.DocumentID, p.Index)
+}
+
+// proposeOperation proposes the operation for the transaction in the form used in the book definition. Incoming
+// transfers titled as donations are proposed as donations, other ones as payments. Outgoing transfers are proposed
+// as purchases, their document number and cost types must be completed.
+func proposeOperation(t types.BankTransaction) string {
+	contractor := fmt.Sprintf("Kontrahent(%q, %q, %q)", t.Counterparty.Name, t.Counterparty.Address,
+		t.Counterparty.TaxID)
+	payment := fmt.Sprintf("Platnosc(%q, %s, %d, %s)", t.DocumentID, dateDSL(t.Date), t.Index, amountDSL(t.Amount))
+
+	switch {
+	case t.Amount.LT(types.NewDenom(t.Amount.Currency)):
+		return fmt.Sprintf("Zakup(%s, Dokument(%q, %s), %s, %s, Platnosci(%s), KUP, Nieodplatna, %q)",
+			dateDSL(t.Date), "?", dateDSL(t.Date), contractor, amountDSL(t.Amount), payment, t.Title)
+	case strings.Contains(strings.ToLower(t.Title), "darowizn"):
+		return fmt.Sprintf("Darowizna(%s, %s)", contractor, payment)
+	default:
+		return fmt.Sprintf("Wplata(%s, %s, %q)", contractor, payment, t.Title)
+	}
+}
+
+func dateDSL(d time.Time) string {
+	return fmt.Sprintf("Data(%d, %d, %d)", d.Year(), d.Month(), d.Day())
+}
+
+func amountDSL(d types.Denom) string {
+	parts := strings.SplitN(d.Abs().Amount.String(), ".", 2)
+	fraction := "0"
+	if len(parts) == 2 {
+		if f := strings.TrimLeft(parts[1], "0"); f != "" {
+			fraction = f
+		}
+	}
+	return fmt.Sprintf("Kwota(%s, %s, %s)", parts[0], fraction, d.Currency)
+}
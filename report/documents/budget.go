@@ -0,0 +1,127 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed budget.tmpl.xml
+	budgetTmpl     string
+	budgetTemplate = template.Must(template.New("budget").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(budgetTmpl))
+)
+
+// BudgetReport compares budgeted amounts with the actual ones.
+type BudgetReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           uint64
+	Date           time.Time
+	Lines          []BudgetReportLine
+}
+
+// BudgetReportLine is the comparison of single budget line.
+type BudgetReportLine struct {
+	Name       string
+	Dimensions string
+	Months     []BudgetRecord
+	YearToDate BudgetRecord
+	Year       BudgetRecord
+}
+
+// BudgetRecord compares budgeted amount with the actual one.
+type BudgetRecord struct {
+	Month          string
+	Budget         types.Denom
+	Actual         types.Denom
+	Variance       types.Denom
+	Utilisation    types.Number
+	HasUtilisation bool
+}
+
+func newBudgetRecord(month string, budget, actual types.Denom) BudgetRecord {
+	r := BudgetRecord{
+		Month:    month,
+		Budget:   budget,
+		Actual:   actual,
+		Variance: actual.Sub(budget),
+	}
+	if budget.NEQ(types.BaseZero) {
+		r.Utilisation = actual.PercentOf(budget)
+		r.HasUtilisation = true
+	}
+	return r
+}
+
+// GenerateBudgetReport generates the report comparing budget with actual amounts, year-to-date as of the end of the
+// period.
+func GenerateBudgetReport(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+	budget types.Budget,
+) types.ReportDocument {
+	report := &BudgetReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Year:           uint64(period.Start.Year()),
+		Date:           period.End,
+	}
+
+	months := period.Months()
+	for _, bl := range budget {
+		line := BudgetReportLine{
+			Name:       bl.Name,
+			Dimensions: dimensionsLabel(bl.Dimensions),
+			Months:     make([]BudgetRecord, 0, len(months)),
+		}
+		for _, month := range months {
+			line.Months = append(line.Months, newBudgetRecord(monthName(month.Month()), bl.Month(month.Month()),
+				coa.BalanceMonth(bl.AccountID, month, bl.Dimensions...)))
+		}
+		actual := coa.BalanceIncremental(bl.AccountID, period.End, bl.Dimensions...)
+		line.YearToDate = newBudgetRecord("", bl.UntilMonth(period.End.Month()), actual)
+		line.Year = newBudgetRecord("", bl.Total(), actual)
+
+		report.Lines = append(report.Lines, line)
+	}
+
+	return types.ReportDocument{
+		Template: budgetTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Budżet",
+			LockedRows: 4,
+		},
+	}
+}
+
+func dimensionsLabel(dimensions []types.Dimension) string {
+	labels := make([]string, 0, len(dimensions))
+	for _, d := range dimensions {
+		labels = append(labels, fmt.Sprintf("%s: %s", dimensionName(d.Key), d.Value))
+	}
+	return strings.Join(labels, ", ")
+}
+
+func dimensionName(key types.DimensionKey) string {
+	switch key {
+	case types.DimensionProject:
+		return "projekt"
+	case types.DimensionGrant:
+		return "dotacja"
+	case types.DimensionEvent:
+		return "wydarzenie"
+	case types.DimensionCostCentre:
+		return "MPK"
+	default:
+		return string(key)
+	}
+}
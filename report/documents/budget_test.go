@@ -0,0 +1,122 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/report"
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+var operationalCosts = types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Operacyjne)
+
+func newChart(period types.Period) *types.ChartOfAccounts {
+	return types.NewChartOfAccounts(period, report.DefaultChartOfAccounts()...)
+}
+
+func bookCost(coa *types.ChartOfAccounts, date time.Time, amount string, project types.Project) {
+	coa.AddEntry(&operations.Purchase{Date: date, Project: project},
+		types.NewEntryRecord(operationalCosts, types.DebitBalance(pln(amount))))
+}
+
+func TestBudgetReport(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		budget      string
+		actual      string
+		variance    string
+		utilisation string
+	}
+
+	coa := newChart(firstQuarter)
+	bookCost(coa, day(2025, time.January, 15), "120.00", "")
+	bookCost(coa, day(2025, time.February, 10), "150.00", "Regaty")
+	bookCost(coa, day(2025, time.April, 5), "50.00", "")
+
+	budget := types.Budget{
+		{
+			Name:      "Koszty operacyjne",
+			AccountID: operationalCosts,
+			Amounts: map[time.Month]types.Denom{
+				time.January:  pln("100.00"),
+				time.February: pln("200.00"),
+				time.March:    pln("300.00"),
+				time.June:     pln("400.00"),
+			},
+		},
+		{
+			Name:       "Regaty",
+			AccountID:  operationalCosts,
+			Dimensions: []types.Dimension{{Key: types.DimensionProject, Value: "Regaty"}},
+			Amounts: map[time.Month]types.Denom{
+				time.February: pln("100.00"),
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		dimensions string
+		months     []record
+		yearToDate record
+		year       record
+	}{
+		{
+			name: "Koszty operacyjne",
+			months: []record{
+				{budget: "100.00", actual: "120.00", variance: "20.00", utilisation: "120.00"},
+				{budget: "200.00", actual: "150.00", variance: "-50.00", utilisation: "75.00"},
+				{budget: "300.00", actual: "0.00", variance: "-300.00", utilisation: "0.00"},
+			},
+			yearToDate: record{budget: "600.00", actual: "270.00", variance: "-330.00", utilisation: "45.00"},
+			year:       record{budget: "1000.00", actual: "270.00", variance: "-730.00", utilisation: "27.00"},
+		},
+		{
+			name:       "Regaty",
+			dimensions: "projekt: Regaty",
+			months: []record{
+				{budget: "0.00", actual: "0.00", variance: "0.00"},
+				{budget: "100.00", actual: "150.00", variance: "50.00", utilisation: "150.00"},
+				{budget: "0.00", actual: "0.00", variance: "0.00"},
+			},
+			yearToDate: record{budget: "100.00", actual: "150.00", variance: "50.00", utilisation: "150.00"},
+			year:       record{budget: "100.00", actual: "150.00", variance: "50.00", utilisation: "150.00"},
+		},
+	}
+
+	assertRecord := func(t *testing.T, name string, expected record, actual documents.BudgetRecord) {
+		t.Helper()
+
+		assertDenom(t, name+" budget", expected.budget, actual.Budget)
+		assertDenom(t, name+" actual", expected.actual, actual.Actual)
+		assertDenom(t, name+" variance", expected.variance, actual.Variance)
+		if actual.HasUtilisation != (expected.utilisation != "") ||
+			(actual.HasUtilisation && actual.Utilisation.String() != expected.utilisation) {
+			t.Errorf("%s utilisation: expected %q, got %t %s", name, expected.utilisation, actual.HasUtilisation,
+				actual.Utilisation)
+		}
+	}
+
+	report := documents.GenerateBudgetReport(firstQuarter, coa, "", "", budget).Data.(*documents.BudgetReport)
+	if len(report.Lines) != len(tests) {
+		t.Fatalf("expected %d lines, got %d", len(tests), len(report.Lines))
+	}
+	for i, tt := range tests {
+		line := report.Lines[i]
+		if line.Name != tt.name || line.Dimensions != tt.dimensions {
+			t.Errorf("line %d: expected %q %q, got %q %q", i, tt.name, tt.dimensions, line.Name, line.Dimensions)
+		}
+		if len(line.Months) != len(tt.months) {
+			t.Fatalf("line %d: expected %d months, got %d", i, len(tt.months), len(line.Months))
+		}
+		for j, m := range tt.months {
+			assertRecord(t, tt.name+" "+line.Months[j].Month, m, line.Months[j])
+		}
+		assertRecord(t, tt.name+" year to date", tt.yearToDate, line.YearToDate)
+		assertRecord(t, tt.name+" year", tt.year, line.Year)
+	}
+}
@@ -0,0 +1,77 @@
+package documents
+
+import (
+	_ "embed"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed chartofaccounts.tmpl.xml
+	chartOfAccountsTmpl     string
+	chartOfAccountsTemplate = template.Must(template.New("chartOfAccounts").Parse(chartOfAccountsTmpl))
+)
+
+// ChartOfAccountsReport lists accounts in the chart.
+type ChartOfAccountsReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Records        []ChartOfAccountsRecord
+}
+
+// ChartOfAccountsRecord describes single account.
+type ChartOfAccountsRecord struct {
+	Account types.AccountInfo
+	Level   uint64
+	Type    string
+}
+
+// Indent returns the number of spaces indenting name of the account according to its level in the tree.
+func (r ChartOfAccountsRecord) Indent() uint64 {
+	return 4 * r.Level
+}
+
+// GenerateChartOfAccountsReport generates the listing of the chart of accounts.
+func GenerateChartOfAccountsReport(
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	report := &ChartOfAccountsReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+	}
+
+	for _, accountID := range coa.AccountIDs() {
+		info := coa.AccountInfo(accountID)
+		report.Records = append(report.Records, ChartOfAccountsRecord{
+			Account: info,
+			Level:   uint64(len(accountID) - 1),
+			Type:    accountTypeName(info.Type),
+		})
+	}
+
+	return types.ReportDocument{
+		Template: chartOfAccountsTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Plan kont",
+			LockedRows: 4,
+		},
+	}
+}
+
+func accountTypeName(accountType types.AccountType) string {
+	switch accountType {
+	case types.Assets:
+		return "aktywa"
+	case types.Liabilities:
+		return "pasywa"
+	case types.Incomes:
+		return "przychody"
+	case types.Costs:
+		return "koszty"
+	default:
+		panic("invalid account type")
+	}
+}
@@ -0,0 +1,37 @@
+package documents_test
+
+import (
+	"testing"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+)
+
+func TestChartOfAccountsReport(t *testing.T) {
+	t.Parallel()
+
+	expected := []struct {
+		number   string
+		name     string
+		level    uint64
+		typeName string
+	}{
+		{number: "130", name: "Bank", typeName: "aktywa"},
+		{number: "400", name: "Koszty", typeName: "koszty"},
+		{number: "400-01", name: "Materiały", level: 1, typeName: "koszty"},
+		{number: "400-02", name: "Usługi", level: 1, typeName: "koszty"},
+		{number: "800", name: "Fundusz", typeName: "pasywa"},
+		{number: "990", name: "Pozabilansowe", typeName: "koszty"},
+	}
+
+	report := documents.GenerateChartOfAccountsReport(smallChart(), "", "").Data.(*documents.ChartOfAccountsReport)
+	if len(report.Records) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(report.Records))
+	}
+	for i, e := range expected {
+		r := report.Records[i]
+		if r.Account.Number != e.number || r.Account.Name != e.name || r.Level != e.level || r.Type != e.typeName {
+			t.Errorf("record %d: expected %s %s level %d %s, got %s %s level %d %s", i, e.number, e.name, e.level,
+				e.typeName, r.Account.Number, r.Account.Name, r.Level, r.Type)
+		}
+	}
+}
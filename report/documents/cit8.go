@@ -2,7 +2,9 @@ package documents
 
 import (
 	_ "embed"
+	"fmt"
 	"text/template"
+	"time"
 
 	"github.com/outofforest/uepik/v2/accounts"
 	"github.com/outofforest/uepik/v2/types"
@@ -12,6 +14,14 @@ var (
 	//go:embed cit8.tmpl.xml
 	cit8Tmpl     string
 	cit8Template = template.Must(template.New("cit8").Parse(cit8Tmpl))
+
+	//go:embed cit8declaration.tmpl.xml
+	cit8DeclarationTmpl     string
+	cit8DeclarationTemplate = template.Must(template.New("cit8Declaration").Funcs(template.FuncMap{
+		"date":  date,
+		"units": units,
+		"xml":   xmlText,
+	}).Parse(cit8DeclarationTmpl))
 )
 
 // CIT8Report is the CIT-8 report.
@@ -24,16 +34,100 @@ type CIT8Report struct {
 	NonTaxableProfitOthers    types.Denom
 	UnspentProfit             types.Denom
 	ReceivedDonations         types.Denom
+
+	// DonorLimit is the CIT-D disclosure limit, Donors are the donors exceeding it.
+	DonorLimit        types.Denom
+	Donors            []DonorDonations
+	DisclosedDonation types.Denom
+}
+
+// CIT8Declaration is the CIT-8 declaration with CIT-8/O and CIT-D attachments.
+type CIT8Declaration struct {
+	CompanyName   string
+	CompanyTaxID  string
+	TaxOfficeCode string
+	Period        types.Period
+	CreatedAt     time.Time
+	Fields        []DeclarationField
+	OFields       []DeclarationField
+	DFields       []DeclarationField
+	Donors        []DonorDonations
 }
 
 // GenerateCIT8Report generates CIT-8 report.
-func GenerateCIT8Report(coa *types.ChartOfAccounts) types.ReportDocument {
+func GenerateCIT8Report(
+	coa *types.ChartOfAccounts,
+	donors []DonorDonations,
+	donorLimit types.Denom,
+) types.ReportDocument {
+	return types.ReportDocument{
+		Data:     newCIT8Report(coa, donors, donorLimit),
+		Template: cit8Template,
+		Config: types.SheetConfig{
+			Name:       "CIT-8",
+			LockedRows: 0,
+		},
+	}
+}
+
+// GenerateCIT8Declaration generates CIT-8(34) declaration of the year together with CIT-8/O(12) and CIT-D(2)
+// attachments in the XML format of e-Deklaracje. Declaration is generated only if the tax office code is set.
+// Declarations don't accept negative amounts, so they are reported as zero.
+func GenerateCIT8Declaration(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	donors []DonorDonations,
+	donorLimit types.Denom,
+	createdAt time.Time,
+	companyName, companyTaxID, taxOfficeCode string,
+) []types.ExportDocument {
+	if taxOfficeCode == "" {
+		return nil
+	}
+
+	report := newCIT8Report(coa, donors, donorLimit)
+	return []types.ExportDocument{{
+		FileName: fmt.Sprintf("CIT-8-%d.xml", period.Start.Year()),
+		Template: cit8DeclarationTemplate,
+		Data: &CIT8Declaration{
+			CompanyName:   companyName,
+			CompanyTaxID:  companyTaxID,
+			TaxOfficeCode: taxOfficeCode,
+			Period:        period,
+			CreatedAt:     createdAt,
+			Fields: []DeclarationField{
+				{Name: "P_52", Value: nonNegative(report.IncomesFinancial)},
+				{Name: "P_53", Value: nonNegative(report.IncomesOthers)},
+				{Name: "P_62", Value: nonNegative(report.CostsFinancial)},
+				{Name: "P_63", Value: nonNegative(report.CostsOthers)},
+			},
+			OFields: []DeclarationField{
+				{Name: "P_13", Value: nonNegative(report.NonTaxableProfitFinancial)},
+				{Name: "P_14", Value: nonNegative(report.NonTaxableProfitOthers)},
+				{Name: "P_192", Value: nonNegative(report.UnspentProfit)},
+			},
+			DFields: []DeclarationField{
+				{Name: "P_21", Value: nonNegative(report.ReceivedDonations)},
+			},
+			Donors: report.Donors,
+		},
+	}}
+}
+
+func nonNegative(denom types.Denom) types.Denom {
+	if denom.LT(types.NewDenom(denom.Currency)) {
+		return types.NewDenom(denom.Currency)
+	}
+	return denom
+}
+
+func newCIT8Report(coa *types.ChartOfAccounts, donors []DonorDonations, donorLimit types.Denom) *CIT8Report {
 	incomesFinancial := coa.Balance(types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe))
 	incomesOthers := coa.Balance(types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne))
 	costsFinancial := coa.Balance(types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe,
 		accounts.Finansowe))
-	costsOthers := coa.Balance(types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe,
-		accounts.Operacyjne))
+	costsOthers := coa.Balance(types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe)).
+		Sub(costsFinancial)
 	nonTaxableProfitFinancial := incomesFinancial.Sub(costsFinancial)
 	if nonTaxableProfitFinancial.LT(types.BaseZero) {
 		nonTaxableProfitFinancial = types.BaseZero
@@ -42,22 +136,23 @@ func GenerateCIT8Report(coa *types.ChartOfAccounts) types.ReportDocument {
 	if nonTaxableProfitOthers.LT(types.BaseZero) {
 		nonTaxableProfitOthers = types.BaseZero
 	}
-	return types.ReportDocument{
-		Data: &CIT8Report{
-			IncomesFinancial:          incomesFinancial,
-			IncomesOthers:             incomesOthers,
-			CostsFinancial:            costsFinancial,
-			CostsOthers:               costsOthers,
-			NonTaxableProfitFinancial: nonTaxableProfitFinancial,
-			NonTaxableProfitOthers:    nonTaxableProfitOthers,
-			UnspentProfit:             coa.Balance(types.NewAccountID(accounts.NiewydatkowanyDochod)),
-			ReceivedDonations: coa.Balance(types.NewAccountID(accounts.PiK, accounts.Przychody,
-				accounts.Operacyjne, accounts.Nieodplatna)),
-		},
-		Template: cit8Template,
-		Config: types.SheetConfig{
-			Name:       "CIT-8",
-			LockedRows: 0,
-		},
+
+	report := &CIT8Report{
+		IncomesFinancial:          incomesFinancial,
+		IncomesOthers:             incomesOthers,
+		CostsFinancial:            costsFinancial,
+		CostsOthers:               costsOthers,
+		NonTaxableProfitFinancial: nonTaxableProfitFinancial,
+		NonTaxableProfitOthers:    nonTaxableProfitOthers,
+		UnspentProfit:             coa.Balance(types.NewAccountID(accounts.NiewydatkowanyDochod)),
+		ReceivedDonations: coa.Balance(types.NewAccountID(accounts.PiK, accounts.Przychody,
+			accounts.Operacyjne, accounts.Nieodplatna)),
+		DonorLimit:        donorLimit,
+		Donors:            DisclosedDonors(donors, donorLimit),
+		DisclosedDonation: types.BaseZero,
+	}
+	for _, d := range report.Donors {
+		report.DisclosedDonation = report.DisclosedDonation.Add(d.BaseTotal)
 	}
+	return report
 }
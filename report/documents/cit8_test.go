@@ -0,0 +1,138 @@
+package documents_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/report"
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func cit8Chart(balances map[string]types.Denom) *types.ChartOfAccounts {
+	coa := types.NewChartOfAccounts(types.Period{
+		Start: day(2025, time.January, 1),
+		End:   day(2025, time.December, 31),
+	}, report.DefaultChartOfAccounts()...)
+
+	ids := map[string]types.AccountID{
+		"interest": types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe, accounts.Odsetki),
+		"donations": types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne,
+			accounts.Nieodplatna),
+		"exchangeLoss": types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Finansowe,
+			accounts.UjemneRozniceKursowe),
+		"unspentProfit": types.NewAccountID(accounts.NiewydatkowanyDochod),
+	}
+	for name, amount := range balances {
+		if name == "exchangeLoss" {
+			coa.OpenAccount(ids[name], types.DebitBalance(amount))
+			continue
+		}
+		coa.OpenAccount(ids[name], types.CreditBalance(amount))
+	}
+	return coa
+}
+
+func TestCIT8Declaration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		balances map[string]types.Denom
+		donors   []documents.DonorDonations
+		unspent  string
+		expected []string
+	}{
+		{
+			name: "positions and disclosed donors",
+			balances: map[string]types.Denom{
+				"interest":      pln("100.40"),
+				"donations":     pln("20000.00"),
+				"exchangeLoss":  pln("30.60"),
+				"unspentProfit": pln("1500.50"),
+			},
+			donors: []documents.DonorDonations{
+				{Donor: types.Contractor{Name: "Kowalski & Syn", TaxID: "5270000000"}, BaseTotal: pln("16000.00")},
+				{Donor: types.Contractor{Name: "Jan Nowak"}, BaseTotal: pln("4000.00")},
+			},
+			unspent: "1500.50",
+			expected: []string{
+				"<P_52>100</P_52>", "<P_53>20000</P_53>", "<P_62>31</P_62>", "<P_63>0</P_63>",
+				"<P_13>70</P_13>", "<P_14>20000</P_14>", "<P_192>1501</P_192>", "<P_21>20000</P_21>",
+				"<P_22>Kowalski &amp; Syn</P_22>", "<P_23>5270000000</P_23>", "<P_25>16000</P_25>",
+			},
+		},
+		{
+			name: "negative amounts reported as zero",
+			balances: map[string]types.Denom{
+				"unspentProfit": pln("-700.00"),
+			},
+			unspent:  "-700.00",
+			expected: []string{"<P_192>0</P_192>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := cit8Chart(tt.balances)
+			period := types.Period{Start: day(2025, time.January, 1), End: day(2025, time.December, 31)}
+
+			sheet := documents.GenerateCIT8Report(coa, tt.donors, pln("15000.00")).Data.(*documents.CIT8Report)
+			assertDenom(t, "unspent profit", tt.unspent, sheet.UnspentProfit)
+
+			exports := documents.GenerateCIT8Declaration(period, coa, tt.donors, pln("15000.00"),
+				day(2026, time.March, 1), "Żaglownia <Bryza>", "5861234567", "1471")
+			if len(exports) != 1 {
+				t.Fatalf("expected 1 declaration, got %d", len(exports))
+			}
+			export := exports[0]
+			if export.FileName != "CIT-8-2025.xml" {
+				t.Errorf("unexpected file name %s", export.FileName)
+			}
+			buf := &bytes.Buffer{}
+			if err := export.Template.Execute(buf, export.Data); err != nil {
+				t.Fatal(err)
+			}
+			decoder := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+			for {
+				_, err := decoder.Token()
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("invalid XML: %s", err)
+				}
+			}
+
+			output := buf.String()
+			for _, e := range append(tt.expected, "<etd:PelnaNazwa>Żaglownia &lt;Bryza&gt;</etd:PelnaNazwa>",
+				"<OkresOd>2025-01-01</OkresOd>", "<OkresDo>2025-12-31</OkresDo>") {
+				if !strings.Contains(output, e) {
+					t.Errorf("declaration does not contain %s", e)
+				}
+			}
+			if strings.Contains(output, "Jan Nowak") {
+				t.Error("donor below the limit is disclosed")
+			}
+		})
+	}
+}
+
+func TestCIT8DeclarationWithoutTaxOffice(t *testing.T) {
+	t.Parallel()
+
+	period := types.Period{Start: day(2025, time.January, 1), End: day(2025, time.December, 31)}
+	exports := documents.GenerateCIT8Declaration(period, cit8Chart(nil), nil, pln("15000.00"),
+		day(2026, time.March, 1), "Żaglownia", "5861234567", "")
+	if len(exports) != 0 {
+		t.Fatalf("expected no declaration, got %d", len(exports))
+	}
+}
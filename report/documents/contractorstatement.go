@@ -0,0 +1,149 @@
+package documents
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed contractorstatement.tmpl.xml
+	contractorStatementTmpl     string
+	contractorStatementTemplate = template.Must(template.New("contractorStatement").Funcs(template.FuncMap{
+		"date":            date,
+		"entryTypeName":   settlementEntryTypeName,
+		"settlementTitle": settlementTitle,
+	}).Parse(contractorStatementTmpl))
+)
+
+// ContractorStatement is the statement of settlements with the contractor.
+type ContractorStatement struct {
+	SheetName      string
+	CompanyName    string
+	CompanyAddress string
+	Year           uint64
+	Contractor     types.Contractor
+	Sections       []ContractorStatementSection
+}
+
+// ContractorStatementSection contains settlements in single currency.
+type ContractorStatementSection struct {
+	Currency    types.CurrencySymbol
+	Records     []ContractorStatementRecord
+	Balance     types.Denom
+	BaseBalance types.Denom
+}
+
+// ContractorStatementRecord is the entry of the statement with running balances.
+type ContractorStatementRecord struct {
+	Entry       types.SettlementEntry
+	Debit       types.Denom
+	Credit      types.Denom
+	Balance     types.Denom
+	BaseDebit   types.Denom
+	BaseCredit  types.Denom
+	BaseBalance types.Denom
+}
+
+// GenerateContractorStatements generates statements of settlements, one sheet per contractor.
+func GenerateContractorStatements(
+	period types.Period,
+	ledgers []*types.ContractorLedger,
+	companyName, companyAddress string,
+) []types.ReportDocument {
+	docs := make([]types.ReportDocument, 0, len(ledgers))
+	names := map[string]struct{}{}
+	for _, l := range ledgers {
+		statement := &ContractorStatement{
+			SheetName:      statementSheetName(l.Contractor, names),
+			CompanyName:    companyName,
+			CompanyAddress: companyAddress,
+			Year:           uint64(period.Start.Year()),
+			Contractor:     l.Contractor,
+		}
+		for _, c := range l.Currencies() {
+			section := ContractorStatementSection{
+				Currency:    c,
+				Balance:     types.NewDenom(c),
+				BaseBalance: types.BaseZero,
+			}
+			for _, e := range l.Entries {
+				if e.Amount.Currency != c || e.Date.After(period.End) {
+					continue
+				}
+				section.Balance = section.Balance.Add(e.Amount)
+				section.BaseBalance = section.BaseBalance.Add(e.BaseAmount)
+				r := ContractorStatementRecord{
+					Entry:       e,
+					Debit:       types.NewDenom(c),
+					Credit:      types.NewDenom(c),
+					Balance:     section.Balance,
+					BaseDebit:   types.BaseZero,
+					BaseCredit:  types.BaseZero,
+					BaseBalance: section.BaseBalance,
+				}
+				if e.Amount.GT(types.NewDenom(c)) {
+					r.Debit = e.Amount
+					r.BaseDebit = e.BaseAmount
+				} else {
+					r.Credit = e.Amount.Neg()
+					r.BaseCredit = e.BaseAmount.Neg()
+				}
+				section.Records = append(section.Records, r)
+			}
+			if len(section.Records) > 0 {
+				statement.Sections = append(statement.Sections, section)
+			}
+		}
+		if len(statement.Sections) == 0 {
+			continue
+		}
+
+		docs = append(docs, types.ReportDocument{
+			Template: contractorStatementTemplate,
+			Data:     statement,
+			Config: types.SheetConfig{
+				Name:       statement.SheetName,
+				LockedRows: 5,
+			},
+		})
+	}
+	return docs
+}
+
+func statementSheetName(contractor types.Contractor, names map[string]struct{}) string {
+	name := "Kartoteka " + strings.NewReplacer(
+		"/", " ", "\\", " ", "?", " ", "*", " ", ":", " ", "[", " ", "]", " ", "'", " ",
+	).Replace(contractor.Name)
+	if _, exists := names[name]; exists {
+		name += " " + contractor.Key()
+	}
+	names[name] = struct{}{}
+	return name
+}
+
+func settlementEntryTypeName(entryType types.SettlementEntryType) string {
+	switch entryType {
+	case types.SettlementInvoice:
+		return "Faktura"
+	case types.SettlementCreditNote:
+		return "Korekta"
+	case types.SettlementPayment:
+		return "Płatność"
+	default:
+		panic("invalid settlement entry type")
+	}
+}
+
+func settlementTitle(balance types.Denom) string {
+	switch {
+	case balance.GT(types.NewDenom(balance.Currency)):
+		return "należność"
+	case balance.LT(types.NewDenom(balance.Currency)):
+		return "zobowiązanie"
+	default:
+		return "rozliczone"
+	}
+}
@@ -0,0 +1,200 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed donationconfirmation.tmpl.xml
+	donationConfirmationTmpl     string
+	donationConfirmationTemplate = template.Must(template.New("donationConfirmation").Funcs(template.FuncMap{
+		"date": date,
+		"xml":  xmlText,
+	}).Parse(donationConfirmationTmpl))
+
+	//go:embed donors.tmpl.xml
+	donorsTmpl     string
+	donorsTemplate = template.Must(template.New("donors").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(donorsTmpl))
+)
+
+// DonorDonations lists donations received from the donor in the period.
+type DonorDonations struct {
+	Donor     types.Contractor
+	Donations []DonationRecord
+	Totals    []types.Denom
+	BaseTotal types.Denom
+}
+
+// DonationRecord is the donation received.
+type DonationRecord struct {
+	Date       time.Time
+	Document   types.DocumentID
+	Amount     types.Denom
+	BaseAmount types.Denom
+	Rate       types.Number
+}
+
+// DonationConfirmation confirms donations received from the donor in the year.
+type DonationConfirmation struct {
+	DonorDonations
+
+	Date           time.Time
+	Year           int
+	CompanyName    string
+	CompanyAddress string
+	CompanyTaxID   string
+	CompanyKRS     string
+	PublicBenefit  bool
+}
+
+// DonorsReport lists donors whose donations exceed the disclosure limit.
+type DonorsReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           int
+	Limit          types.Denom
+	Donors         []DonorDonations
+	BaseTotal      types.Denom
+}
+
+type donationSource interface {
+	types.EntryDataSource
+
+	DonatedAmount() types.Denom
+}
+
+// Donors returns donations received in the period grouped by donors. Amounts are converted to the base currency
+// the same way they are booked.
+func Donors(period types.Period, operations []types.Operation, rates types.CurrencyRates) []DonorDonations {
+	donors := map[string]*DonorDonations{}
+	for _, op := range operations {
+		source, ok := op.(donationSource)
+		if !ok || !period.Contains(source.GetDate()) {
+			continue
+		}
+
+		donor, exists := donors[source.GetContractor().Key()]
+		if !exists {
+			donor = &DonorDonations{
+				Donor:     source.GetContractor(),
+				BaseTotal: types.BaseZero,
+			}
+			donors[source.GetContractor().Key()] = donor
+		}
+
+		amount := source.DonatedAmount()
+		baseAmount, rate := rates.ToBase(amount, types.PreviousDay(source.GetDate()))
+		donor.Donations = append(donor.Donations, DonationRecord{
+			Date:       source.GetDate(),
+			Document:   source.GetDocument().ID,
+			Amount:     amount,
+			BaseAmount: baseAmount,
+			Rate:       rate,
+		})
+		donor.BaseTotal = donor.BaseTotal.Add(baseAmount)
+		donor.Totals = addDenom(donor.Totals, amount)
+	}
+
+	result := make([]DonorDonations, 0, len(donors))
+	for _, d := range donors {
+		sort.SliceStable(d.Donations, func(i, j int) bool {
+			return d.Donations[i].Date.Before(d.Donations[j].Date)
+		})
+		result = append(result, *d)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Donor.Name != result[j].Donor.Name {
+			return strings.Compare(result[i].Donor.Name, result[j].Donor.Name) < 0
+		}
+		return strings.Compare(result[i].Donor.Key(), result[j].Donor.Key()) < 0
+	})
+	return result
+}
+
+// GenerateDonationConfirmations generates confirmations of donations received from each donor in the year.
+func GenerateDonationConfirmations(
+	period types.Period,
+	donors []DonorDonations,
+	date time.Time,
+	companyName, companyAddress, companyTaxID, companyKRS string,
+	publicBenefit bool,
+) []types.ExportDocument {
+	exports := make([]types.ExportDocument, 0, len(donors))
+	for _, d := range donors {
+		exports = append(exports, types.ExportDocument{
+			FileName: fmt.Sprintf("Potwierdzenie-darowizn-%s-%d.fodt", fileNamePart(d.Donor.Key()),
+				period.Start.Year()),
+			Template: donationConfirmationTemplate,
+			Data: &DonationConfirmation{
+				DonorDonations: d,
+				Date:           date,
+				Year:           period.Start.Year(),
+				CompanyName:    companyName,
+				CompanyAddress: companyAddress,
+				CompanyTaxID:   companyTaxID,
+				CompanyKRS:     companyKRS,
+				PublicBenefit:  publicBenefit,
+			},
+		})
+	}
+	return exports
+}
+
+// DisclosedDonors returns donors whose donations in total exceed the CIT-D disclosure limit.
+func DisclosedDonors(donors []DonorDonations, limit types.Denom) []DonorDonations {
+	disclosed := []DonorDonations{}
+	for _, d := range donors {
+		if d.BaseTotal.GT(limit) {
+			disclosed = append(disclosed, d)
+		}
+	}
+	return disclosed
+}
+
+// GenerateDonorsReport generates the list of donors whose donations in the year exceed the CIT-D disclosure limit.
+func GenerateDonorsReport(
+	period types.Period,
+	donors []DonorDonations,
+	limit types.Denom,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	report := &DonorsReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Year:           period.Start.Year(),
+		Limit:          limit,
+		Donors:         DisclosedDonors(donors, limit),
+		BaseTotal:      types.BaseZero,
+	}
+	for _, d := range report.Donors {
+		report.BaseTotal = report.BaseTotal.Add(d.BaseTotal)
+	}
+
+	return types.ReportDocument{
+		Template: donorsTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Darczyńcy",
+			LockedRows: 4,
+		},
+	}
+}
+
+func addDenom(denoms []types.Denom, amount types.Denom) []types.Denom {
+	for i, d := range denoms {
+		if d.Currency == amount.Currency {
+			denoms[i] = d.Add(amount)
+			return denoms
+		}
+	}
+	return append(denoms, amount)
+}
@@ -0,0 +1,177 @@
+package documents_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+var (
+	donorA = types.Contractor{Name: "Anna & Syn", TaxID: "5270000000"}
+	donorB = types.Contractor{Name: "Bosman"}
+)
+
+func donation(donor types.Contractor, date time.Time, id types.DocumentID, amount types.Denom) *operations.Donation {
+	return &operations.Donation{
+		Contractor: donor,
+		Payment:    types.Payment{Date: date, DocumentID: id, Amount: amount},
+	}
+}
+
+func eur(amount string) types.Denom {
+	d, err := types.ParseDenom(amount, types.EUR)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestDonors(t *testing.T) {
+	t.Parallel()
+
+	rates := types.CurrencyRates{
+		{Currency: types.EUR, Date: day(2025, time.February, 9)}: types.NewNumber(4, 2000, 4),
+	}
+	ops := []types.Operation{
+		donation(donorB, day(2025, time.March, 1), "WB/3", pln("300.00")),
+		donation(donorA, day(2025, time.February, 10), "WB/2", eur("100.00")),
+		donation(donorA, day(2025, time.January, 5), "WB/1", pln("50.00")),
+		donation(donorA, day(2025, time.April, 1), "WB/4", pln("1000.00")),
+		receivable(day(2025, time.January, 5), "FS/1", dues(day(2025, time.January, 5), "10.00")),
+	}
+
+	type record struct {
+		document   types.DocumentID
+		baseAmount string
+	}
+
+	expected := []struct {
+		donor     types.Contractor
+		records   []record
+		totals    []types.Denom
+		baseTotal string
+	}{
+		{
+			donor:     donorA,
+			records:   []record{{document: "WB/1", baseAmount: "50.00"}, {document: "WB/2", baseAmount: "420.00"}},
+			totals:    []types.Denom{pln("50.00"), eur("100.00")},
+			baseTotal: "470.00",
+		},
+		{
+			donor:     donorB,
+			records:   []record{{document: "WB/3", baseAmount: "300.00"}},
+			totals:    []types.Denom{pln("300.00")},
+			baseTotal: "300.00",
+		},
+	}
+
+	donors := documents.Donors(firstQuarter, ops, rates)
+	if len(donors) != len(expected) {
+		t.Fatalf("expected %d donors, got %d", len(expected), len(donors))
+	}
+	for i, e := range expected {
+		d := donors[i]
+		if d.Donor.Name != e.donor.Name {
+			t.Fatalf("donor %d: expected %s, got %s", i, e.donor.Name, d.Donor.Name)
+		}
+		if len(d.Donations) != len(e.records) {
+			t.Fatalf("%s: expected %d donations, got %d", d.Donor.Name, len(e.records), len(d.Donations))
+		}
+		for j, r := range e.records {
+			if d.Donations[j].Document != r.document {
+				t.Errorf("%s donation %d: expected %s, got %s", d.Donor.Name, j, r.document, d.Donations[j].Document)
+			}
+			assertDenom(t, d.Donor.Name+" base amount", r.baseAmount, d.Donations[j].BaseAmount)
+		}
+		if len(d.Totals) != len(e.totals) {
+			t.Fatalf("%s: expected %d totals, got %d", d.Donor.Name, len(e.totals), len(d.Totals))
+		}
+		for _, total := range e.totals {
+			found := false
+			for _, actual := range d.Totals {
+				if actual.Currency == total.Currency {
+					found = actual.EQ(total)
+				}
+			}
+			if !found {
+				t.Errorf("%s: expected total %s %s, got %v", d.Donor.Name, total.Amount, total.Currency, d.Totals)
+			}
+		}
+		assertDenom(t, d.Donor.Name+" base total", e.baseTotal, d.BaseTotal)
+	}
+}
+
+func TestDisclosedDonors(t *testing.T) {
+	t.Parallel()
+
+	donors := documents.Donors(firstQuarter, []types.Operation{
+		donation(donorA, day(2025, time.January, 5), "WB/1", pln("5000.00")),
+		donation(donorA, day(2025, time.February, 5), "WB/2", pln("5000.00")),
+		donation(donorB, day(2025, time.January, 5), "WB/3", pln("5000.00")),
+	}, nil)
+
+	tests := []struct {
+		name     string
+		limit    string
+		expected []string
+	}{
+		{name: "all above the limit", limit: "4999.99", expected: []string{donorA.Name, donorB.Name}},
+		{name: "limit not exceeded", limit: "5000.00", expected: []string{donorA.Name}},
+		{name: "none above the limit", limit: "10000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			disclosed := documents.DisclosedDonors(donors, pln(tt.limit))
+			if len(disclosed) != len(tt.expected) {
+				t.Fatalf("expected %d donors, got %d", len(tt.expected), len(disclosed))
+			}
+			for i, name := range tt.expected {
+				if disclosed[i].Donor.Name != name {
+					t.Errorf("donor %d: expected %s, got %s", i, name, disclosed[i].Donor.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestDonationConfirmations(t *testing.T) {
+	t.Parallel()
+
+	donors := documents.Donors(firstQuarter, []types.Operation{
+		donation(donorA, day(2025, time.January, 5), "WB/1", pln("100.00")),
+		donation(donorB, day(2025, time.January, 6), "WB/2", pln("200.00")),
+	}, nil)
+
+	exports := documents.GenerateDonationConfirmations(firstQuarter, donors, day(2025, time.April, 1),
+		"Klub <Żeglarski>", "Gdańsk", "5861234567", "0000123456", true)
+
+	expected := []string{"Potwierdzenie-darowizn-5270000000-2025.fodt", "Potwierdzenie-darowizn-Bosman-2025.fodt"}
+	if len(exports) != len(expected) {
+		t.Fatalf("expected %d confirmations, got %d", len(expected), len(exports))
+	}
+	for i, export := range exports {
+		if export.FileName != expected[i] {
+			t.Errorf("confirmation %d: expected %s, got %s", i, expected[i], export.FileName)
+		}
+		doc := export.Data.(*documents.DonationConfirmation)
+		if doc.Year != 2025 || doc.Donor.Name != donors[i].Donor.Name || !doc.PublicBenefit {
+			t.Errorf("confirmation %d: unexpected data %d %s %t", i, doc.Year, doc.Donor.Name, doc.PublicBenefit)
+		}
+
+		buf := &bytes.Buffer{}
+		if err := export.Template.Execute(buf, export.Data); err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(buf.String(), "<Żeglarski>") || strings.Contains(buf.String(), "Anna & Syn") {
+			t.Errorf("confirmation %d: names are not escaped", i)
+		}
+	}
+}
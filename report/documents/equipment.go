@@ -0,0 +1,120 @@
+package documents
+
+import (
+	_ "embed"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed equipment.tmpl.xml
+	equipmentTmpl     string
+	equipmentTemplate = template.Must(template.New("equipment").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(equipmentTmpl))
+)
+
+// EquipmentReport is the inventory listing of low-value equipment.
+type EquipmentReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Date           time.Time
+	Records        []EquipmentRecord
+}
+
+// EquipmentRecord is the record in the equipment listing.
+type EquipmentRecord struct {
+	Index    uint64
+	Date     time.Time
+	Document types.Document
+	Item     types.EquipmentItem
+	Disposal *types.EquipmentDisposal
+}
+
+type equipmentSource interface {
+	GetDate() time.Time
+	GetDocument() types.Document
+	GetEquipment() []types.EquipmentItem
+}
+
+type equipmentDisposalSource interface {
+	GetEquipmentDisposal() types.EquipmentDisposal
+}
+
+// GenerateEquipmentReport generates the inventory listing of low-value equipment at the end of the period.
+func GenerateEquipmentReport(
+	period types.Period,
+	companyName, companyAddress string,
+	operations []types.Operation,
+) types.ReportDocument {
+	records := []EquipmentRecord{}
+	disposals := map[string]types.EquipmentDisposal{}
+	for _, op := range operations {
+		switch source := op.(type) {
+		case equipmentSource:
+			if period.End.Before(source.GetDate()) {
+				continue
+			}
+			for _, item := range source.GetEquipment() {
+				records = append(records, EquipmentRecord{
+					Date:     source.GetDate(),
+					Document: source.GetDocument(),
+					Item:     item,
+				})
+			}
+		case equipmentDisposalSource:
+			disposal := source.GetEquipmentDisposal()
+			if period.End.Before(disposal.Date) {
+				continue
+			}
+			if _, exists := disposals[disposal.InventoryNumber]; exists {
+				panic("equipment item disposed twice")
+			}
+			disposals[disposal.InventoryNumber] = disposal
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Date.Before(records[j].Date)
+	})
+
+	report := &EquipmentReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Date:           period.End,
+		Records:        make([]EquipmentRecord, 0, len(records)),
+	}
+	inventoryNumbers := map[string]struct{}{}
+	for _, r := range records {
+		if _, exists := inventoryNumbers[r.Item.InventoryNumber]; exists {
+			panic("duplicated inventory number")
+		}
+		inventoryNumbers[r.Item.InventoryNumber] = struct{}{}
+
+		if disposal, exists := disposals[r.Item.InventoryNumber]; exists {
+			if disposal.Date.Before(period.Start) {
+				continue
+			}
+			r.Disposal = &disposal
+		}
+		r.Index = uint64(len(report.Records) + 1)
+		report.Records = append(report.Records, r)
+	}
+	for inventoryNumber := range disposals {
+		if _, exists := inventoryNumbers[inventoryNumber]; !exists {
+			panic("disposed equipment item does not exist")
+		}
+	}
+
+	return types.ReportDocument{
+		Template: equipmentTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Wyposażenie",
+			LockedRows: 4,
+		},
+	}
+}
@@ -0,0 +1,225 @@
+package documents
+
+import (
+	_ "embed"
+	"sort"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed fixedassets.tmpl.xml
+	fixedAssetsTmpl     string
+	fixedAssetsTemplate = template.Must(template.New("fixedAssets").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(fixedAssetsTmpl))
+
+	//go:embed depreciationplan.tmpl.xml
+	depreciationPlanTmpl     string
+	depreciationPlanTemplate = template.Must(template.New("depreciationPlan").Parse(depreciationPlanTmpl))
+)
+
+// FixedAssetsReport is the register of fixed assets.
+type FixedAssetsReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Records        []FixedAssetRecord
+	Summary        FixedAssetSummary
+}
+
+// FixedAssetRecord is the record in the register of fixed assets.
+type FixedAssetRecord struct {
+	Index              uint64
+	Asset              types.FixedAsset
+	Method             string
+	OpeningAccumulated types.Denom
+	PeriodDepreciation types.Denom
+	ClosingAccumulated types.Denom
+	NetValue           types.Denom
+}
+
+// NewFixedAssetSummary creates new fixed asset summary.
+func NewFixedAssetSummary() FixedAssetSummary {
+	return FixedAssetSummary{
+		InitialValue:       types.BaseZero,
+		OpeningAccumulated: types.BaseZero,
+		PeriodDepreciation: types.BaseZero,
+		ClosingAccumulated: types.BaseZero,
+		NetValue:           types.BaseZero,
+	}
+}
+
+// FixedAssetSummary is the summary of the register of fixed assets.
+type FixedAssetSummary struct {
+	InitialValue       types.Denom
+	OpeningAccumulated types.Denom
+	PeriodDepreciation types.Denom
+	ClosingAccumulated types.Denom
+	NetValue           types.Denom
+}
+
+// AddRecord adds record to the summary.
+func (fas FixedAssetSummary) AddRecord(r FixedAssetRecord) FixedAssetSummary {
+	fas.InitialValue = fas.InitialValue.Add(r.Asset.InitialValue)
+	fas.OpeningAccumulated = fas.OpeningAccumulated.Add(r.OpeningAccumulated)
+	fas.PeriodDepreciation = fas.PeriodDepreciation.Add(r.PeriodDepreciation)
+	fas.ClosingAccumulated = fas.ClosingAccumulated.Add(r.ClosingAccumulated)
+	fas.NetValue = fas.NetValue.Add(r.NetValue)
+	return fas
+}
+
+// DepreciationPlanReport is the depreciation plan of fixed assets.
+type DepreciationPlanReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Assets         []DepreciationPlanAsset
+}
+
+// DepreciationPlanAsset is the depreciation plan of single fixed asset.
+type DepreciationPlanAsset struct {
+	Asset   types.FixedAsset
+	Method  string
+	Records []DepreciationPlanRecord
+}
+
+// DepreciationPlanRecord is the depreciation write-off in the plan.
+type DepreciationPlanRecord struct {
+	Year         uint64
+	Month        string
+	InPeriod     bool
+	Depreciation types.Depreciation
+}
+
+type fixedAssetSource interface {
+	GetFixedAsset(rates types.CurrencyRates) types.FixedAsset
+}
+
+// GenerateFixedAssetsReport generates the register of fixed assets.
+func GenerateFixedAssetsReport(
+	period types.Period,
+	companyName, companyAddress string,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+) types.ReportDocument {
+	report := &FixedAssetsReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Summary:        NewFixedAssetSummary(),
+	}
+
+	for i, asset := range fixedAssets(period, operations, rates) {
+		r := FixedAssetRecord{
+			Index:              uint64(i + 1),
+			Asset:              asset,
+			Method:             depreciationMethodName(asset.Method),
+			OpeningAccumulated: types.BaseZero,
+			PeriodDepreciation: types.BaseZero,
+			NetValue:           asset.InitialValue,
+		}
+		for _, d := range asset.DepreciationPlan() {
+			date := d.GetDate()
+			switch {
+			case date.Before(period.Start):
+				r.OpeningAccumulated = r.OpeningAccumulated.Add(d.Amount)
+			case period.Contains(date):
+				r.PeriodDepreciation = r.PeriodDepreciation.Add(d.Amount)
+			default:
+				continue
+			}
+			r.NetValue = d.NetValue
+		}
+		r.ClosingAccumulated = r.OpeningAccumulated.Add(r.PeriodDepreciation)
+
+		report.Records = append(report.Records, r)
+		report.Summary = report.Summary.AddRecord(r)
+	}
+
+	return types.ReportDocument{
+		Template: fixedAssetsTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "ŚT",
+			LockedRows: 4,
+		},
+	}
+}
+
+// GenerateDepreciationPlanReport generates depreciation plan of fixed assets.
+func GenerateDepreciationPlanReport(
+	period types.Period,
+	companyName, companyAddress string,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+) types.ReportDocument {
+	report := &DepreciationPlanReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+	}
+
+	for _, asset := range fixedAssets(period, operations, rates) {
+		plan := asset.DepreciationPlan()
+		a := DepreciationPlanAsset{
+			Asset:   asset,
+			Method:  depreciationMethodName(asset.Method),
+			Records: make([]DepreciationPlanRecord, 0, len(plan)),
+		}
+		for _, d := range plan {
+			a.Records = append(a.Records, DepreciationPlanRecord{
+				Year:         uint64(d.Month.Year()),
+				Month:        monthName(d.Month.Month()),
+				InPeriod:     period.Contains(d.GetDate()),
+				Depreciation: d,
+			})
+		}
+		report.Assets = append(report.Assets, a)
+	}
+
+	return types.ReportDocument{
+		Template: depreciationPlanTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Plan amortyzacji",
+			LockedRows: 4,
+		},
+	}
+}
+
+func fixedAssets(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+) []types.FixedAsset {
+	assets := []types.FixedAsset{}
+	for _, op := range operations {
+		fixedAssetSource, ok := op.(fixedAssetSource)
+		if !ok {
+			continue
+		}
+		asset := fixedAssetSource.GetFixedAsset(rates)
+		if period.End.Before(asset.Date) {
+			continue
+		}
+		assets = append(assets, asset)
+	}
+
+	sort.SliceStable(assets, func(i, j int) bool {
+		return assets[i].Date.Before(assets[j].Date) ||
+			(assets[i].Date.Equal(assets[j].Date) && assets[i].InventoryNumber < assets[j].InventoryNumber)
+	})
+
+	return assets
+}
+
+func depreciationMethodName(method types.DepreciationMethod) string {
+	switch method {
+	case types.DepreciationMethodLinear:
+		return "liniowa"
+	case types.DepreciationMethodOneOff:
+		return "jednorazowa"
+	case types.DepreciationMethodReducing:
+		return "degresywna"
+	default:
+		panic("invalid depreciation method")
+	}
+}
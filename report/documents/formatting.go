@@ -1,12 +1,24 @@
 package documents
 
 import (
+	"encoding/xml"
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/outofforest/uepik/v2/types"
 )
 
+// xmlText escapes the value to be used as the text of the XML element.
+func xmlText(value any) string {
+	b := &strings.Builder{}
+	if err := xml.EscapeText(b, []byte(fmt.Sprint(value))); err != nil {
+		panic(err)
+	}
+	return b.String()
+}
+
 func notZero(n types.Number) bool {
 	return !n.IsZero()
 }
@@ -66,3 +78,7 @@ func findRecords[T withDate](records *[]T, month time.Time, count uint64) []T {
 func page[T any](slice []T) uint64 {
 	return uint64(len(slice) + 1)
 }
+
+func units(denom types.Denom) string {
+	return fmt.Sprintf("%.0f", denom.RoundUnits().Amount.ToFloat64())
+}
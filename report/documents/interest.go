@@ -0,0 +1,289 @@
+package documents
+
+import (
+	_ "embed"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed interest.tmpl.xml
+	lateInterestTmpl     string
+	lateInterestTemplate = template.Must(template.New("lateInterest").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(lateInterestTmpl))
+)
+
+// LateInterestReport lists statutory interest on receivables paid after the due date.
+type LateInterestReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Date           time.Time
+	InterestType   string
+	Records        []LateInterestRecord
+	Totals         []LateInterestTotal
+}
+
+// LateInterestRecord is the part of the due paid after the due date or not paid yet. RateUnknown is set if interest
+// can't be calculated because statutory rates applicable in the period are not known.
+type LateInterestRecord struct {
+	Contractor  types.Contractor
+	Document    types.Document
+	Project     types.Project
+	DueDate     time.Time
+	PaymentDate time.Time
+	Paid        bool
+	Days        uint64
+	Amount      types.Denom
+	Interest    types.Denom
+	RateUnknown bool
+}
+
+// LateInterestTotal sums interest in the currency.
+type LateInterestTotal struct {
+	Paid   types.Denom
+	Unpaid types.Denom
+}
+
+type lateInterestSettlement struct {
+	date       time.Time
+	amount     types.Denom
+	correction bool
+}
+
+type lateInterestGroup struct {
+	document    types.Document
+	contractor  types.Contractor
+	project     types.Project
+	payable     bool
+	dues        []types.Due
+	settlements []lateInterestSettlement
+}
+
+// LateInterests returns statutory interest on receivables of sells as of the date. Payments and credit notes are
+// settled against the earliest dues of the corrected document. Interest is charged on parts of dues paid after the
+// due date, up to the payment date, and on parts not paid yet, up to the date. Amounts corrected by credit notes bear
+// no interest. Receivables and payables having the same document ID are settled separately.
+func LateInterests(interestType types.InterestType, date time.Time, operations []types.Operation) []LateInterestRecord {
+	groups := map[string]*lateInterestGroup{}
+	order := []string{}
+	for _, op := range operations {
+		source, ok := op.(overDueSource)
+		if !ok || source.GetDate().After(date) {
+			continue
+		}
+
+		document := source.GetDocument()
+		corrected := source.GetCorrectedDocument()
+		settledID := document.ID
+		if corrected != "" {
+			settledID = corrected
+		}
+
+		key := source.GetContractor().Key() + "/" + string(settledID)
+		payable := isPayable(source)
+		if payable {
+			key += "/payable"
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &lateInterestGroup{
+				document: types.Document{
+					ID: settledID,
+				},
+				contractor: source.GetContractor(),
+				payable:    payable,
+			}
+			groups[key] = group
+			order = append(order, key)
+		}
+
+		if corrected == "" {
+			group.document = document
+			if ps, ok := op.(projectSource); ok {
+				group.project = ps.GetProject()
+			}
+			group.dues = append(group.dues, source.GetDues()...)
+		} else {
+			for _, d := range source.GetDues() {
+				group.settlements = append(group.settlements, lateInterestSettlement{
+					date:       source.GetDate(),
+					amount:     d.Amount.Neg(),
+					correction: true,
+				})
+			}
+		}
+		for _, p := range source.GetPayments() {
+			if !p.Date.After(date) {
+				group.settlements = append(group.settlements, lateInterestSettlement{
+					date:   p.Date,
+					amount: p.Amount,
+				})
+			}
+		}
+	}
+
+	records := []LateInterestRecord{}
+	for _, key := range order {
+		group := groups[key]
+		if group.payable {
+			continue
+		}
+
+		dues := append([]types.Due{}, group.dues...)
+		sort.SliceStable(dues, func(i, j int) bool {
+			return dues[i].Date.Before(dues[j].Date)
+		})
+		settlements := group.settlements
+		sort.SliceStable(settlements, func(i, j int) bool {
+			return settlements[i].date.Before(settlements[j].date)
+		})
+
+		// Refunds and other negative settlements return overpayments, so they don't change the dues.
+		for _, s := range settlements {
+			left := s.amount
+			for i, d := range dues {
+				if !left.GT(types.NewDenom(left.Currency)) {
+					break
+				}
+				if d.Amount.Currency != left.Currency || d.Amount.Amount.IsZero() {
+					continue
+				}
+
+				part := d.Amount
+				if left.LT(part) {
+					part = left
+				}
+				dues[i].Amount = d.Amount.Sub(part)
+				left = left.Sub(part)
+
+				if s.correction {
+					continue
+				}
+				if days := types.DaysBetween(d.Date, s.date); days > 0 {
+					interest, known := lateInterest(interestType, part, d.Date, s.date)
+					records = append(records, LateInterestRecord{
+						Contractor:  group.contractor,
+						Document:    group.document,
+						Project:     group.project,
+						DueDate:     d.Date,
+						PaymentDate: s.date,
+						Paid:        true,
+						Days:        days,
+						Amount:      part,
+						Interest:    interest,
+						RateUnknown: !known,
+					})
+				}
+			}
+		}
+
+		for _, d := range dues {
+			if d.Amount.Amount.IsZero() {
+				continue
+			}
+			if days := types.DaysBetween(d.Date, date); days > 0 {
+				interest, known := lateInterest(interestType, d.Amount, d.Date, date)
+				records = append(records, LateInterestRecord{
+					Contractor:  group.contractor,
+					Document:    group.document,
+					Project:     group.project,
+					DueDate:     d.Date,
+					Days:        days,
+					Amount:      d.Amount,
+					Interest:    interest,
+					RateUnknown: !known,
+				})
+			}
+		}
+	}
+
+	return records
+}
+
+// GenerateLateInterestReport generates the report of statutory interest on late receivables.
+func GenerateLateInterestReport(
+	period types.Period,
+	interestType types.InterestType,
+	operations []types.Operation,
+	companyName, companyAddress string,
+) []types.ReportDocument {
+	if interestType == types.InterestTypeNone {
+		return nil
+	}
+
+	report := &LateInterestReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Date:           period.End,
+		InterestType:   interestTypeName(interestType),
+	}
+	totals := map[types.CurrencySymbol]*LateInterestTotal{}
+	currencies := []types.CurrencySymbol{}
+	for _, r := range LateInterests(interestType, period.End, operations) {
+		if r.Paid && !period.Contains(r.PaymentDate) {
+			continue
+		}
+		report.Records = append(report.Records, r)
+
+		total, exists := totals[r.Interest.Currency]
+		if !exists {
+			total = &LateInterestTotal{
+				Paid:   types.NewDenom(r.Interest.Currency),
+				Unpaid: types.NewDenom(r.Interest.Currency),
+			}
+			totals[r.Interest.Currency] = total
+			currencies = append(currencies, r.Interest.Currency)
+		}
+		if r.Paid {
+			total.Paid = total.Paid.Add(r.Interest)
+		} else {
+			total.Unpaid = total.Unpaid.Add(r.Interest)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return currencies[i] < currencies[j]
+	})
+	for _, c := range currencies {
+		report.Totals = append(report.Totals, *totals[c])
+	}
+
+	return []types.ReportDocument{
+		{
+			Template: lateInterestTemplate,
+			Data:     report,
+			Config: types.SheetConfig{
+				Name:       "Odsetki",
+				LockedRows: 4,
+			},
+		},
+	}
+}
+
+// lateInterest returns statutory interest on the amount. False is returned if the rates applicable in the period are
+// not known.
+func lateInterest(
+	interestType types.InterestType,
+	amount types.Denom,
+	dueDate, paymentDate time.Time,
+) (types.Denom, bool) {
+	interest, err := types.StatutoryInterest(interestType, amount, dueDate, paymentDate)
+	if err != nil {
+		return types.NewDenom(amount.Currency), false
+	}
+	return interest, true
+}
+
+func interestTypeName(interestType types.InterestType) string {
+	switch interestType {
+	case types.InterestTypeDelay:
+		return "odsetki ustawowe za opóźnienie"
+	case types.InterestTypeCommercial:
+		return "odsetki ustawowe za opóźnienie w transakcjach handlowych"
+	default:
+		panic("invalid interest type")
+	}
+}
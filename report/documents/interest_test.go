@@ -0,0 +1,190 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+var contractor = types.Contractor{Name: "Żaglownia", TaxID: "5861234567"}
+
+func receivable(
+	date time.Time,
+	id types.DocumentID,
+	dues []types.Due,
+	payments ...types.Payment,
+) *operations.Sell {
+	amount := types.BaseZero
+	for _, d := range dues {
+		amount = amount.Add(d.Amount)
+	}
+	return &operations.Sell{
+		Date:       date,
+		Document:   types.Document{ID: id, Date: date},
+		Contractor: contractor,
+		Dues:       dues,
+		Payments:   payments,
+		Lines:      []types.InvoiceLine{sellLine(types.VATRateNotRelevant, amount.Amount.String())},
+	}
+}
+
+func payable(date, dueDate time.Time, id types.DocumentID, amount string) *operations.Purchase {
+	return &operations.Purchase{
+		Date:       date,
+		DueDate:    dueDate,
+		Document:   types.Document{ID: id, Date: date},
+		Contractor: contractor,
+		Amount:     pln(amount),
+	}
+}
+
+func dues(date time.Time, amount string) []types.Due {
+	return []types.Due{{Date: date, Amount: pln(amount)}}
+}
+
+func paid(date time.Time, amount string) types.Payment {
+	return types.Payment{Date: date, Amount: pln(amount)}
+}
+
+func TestLateInterests(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		paid        bool
+		days        uint64
+		amount      string
+		interest    string
+		rateUnknown bool
+	}
+
+	creditNote := receivable(day(2025, time.February, 10), "FK/1", dues(day(2025, time.January, 31), "-300.00"))
+	creditNote.CorrectedDocument = "FS/1"
+
+	tests := []struct {
+		name         string
+		interestType types.InterestType
+		date         time.Time
+		operations   []types.Operation
+		records      []record
+	}{
+		{
+			name:         "paid on time",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00"),
+					paid(day(2025, time.January, 31), "1000.00")),
+			},
+		},
+		{
+			name:         "paid late",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00"),
+					paid(day(2025, time.March, 2), "1000.00")),
+			},
+			records: []record{
+				{paid: true, days: 30, amount: "1000.00", interest: "9.25"},
+			},
+		},
+		{
+			name:         "commercial transaction paid late",
+			interestType: types.InterestTypeCommercial,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00"),
+					paid(day(2025, time.March, 2), "1000.00")),
+			},
+			records: []record{
+				{paid: true, days: 30, amount: "1000.00", interest: "12.95"},
+			},
+		},
+		{
+			name:         "partially paid late, rest unpaid",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00"),
+					paid(day(2025, time.March, 2), "400.00")),
+			},
+			records: []record{
+				{paid: true, days: 30, amount: "400.00", interest: "3.70"},
+				{days: 60, amount: "600.00", interest: "11.10"},
+			},
+		},
+		{
+			name:         "credit note bears no interest",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00"),
+					paid(day(2025, time.March, 2), "700.00")),
+				creditNote,
+			},
+			records: []record{
+				{paid: true, days: 30, amount: "700.00", interest: "6.47"},
+			},
+		},
+		{
+			name:         "payables bear no interest",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.April, 1),
+			operations: []types.Operation{
+				payable(day(2025, time.January, 10), day(2025, time.January, 31), "FZ/1", "1000.00"),
+			},
+		},
+		{
+			name:         "receivable and payable with the same document ID",
+			interestType: types.InterestTypeDelay,
+			date:         day(2025, time.March, 2),
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "1/2025", dues(day(2025, time.January, 31), "1000.00")),
+				payable(day(2025, time.January, 10), day(2025, time.January, 31), "1/2025", "500.00"),
+			},
+			records: []record{
+				{days: 30, amount: "1000.00", interest: "9.25"},
+			},
+		},
+		{
+			name:         "after the end of the rate table",
+			interestType: types.InterestTypeDelay,
+			date:         day(2026, time.March, 1),
+			operations: []types.Operation{
+				receivable(day(2025, time.November, 10), "FS/1", dues(day(2025, time.December, 1), "1000.00")),
+				receivable(day(2025, time.November, 10), "FS/2", dues(day(2025, time.November, 20), "1000.00"),
+					paid(day(2025, time.December, 10), "1000.00")),
+			},
+			records: []record{
+				{days: 90, amount: "1000.00", interest: "0.00", rateUnknown: true},
+				{paid: true, days: 20, amount: "1000.00", interest: "5.29"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			records := documents.LateInterests(tt.interestType, tt.date, tt.operations)
+			if len(records) != len(tt.records) {
+				t.Fatalf("expected %d records, got %d", len(tt.records), len(records))
+			}
+			for i, r := range records {
+				e := tt.records[i]
+				if r.Paid != e.paid || r.Days != e.days || r.RateUnknown != e.rateUnknown {
+					t.Errorf("record %d: expected paid %t, days %d, unknown rate %t, got %t, %d, %t", i, e.paid,
+						e.days, e.rateUnknown, r.Paid, r.Days, r.RateUnknown)
+				}
+				assertDenom(t, "amount", e.amount, r.Amount)
+				assertDenom(t, "interest", e.interest, r.Interest)
+			}
+
+			period := types.Period{Start: day(tt.date.Year(), time.January, 1), End: tt.date}
+			documents.GenerateLateInterestReport(period, tt.interestType, tt.operations, "", "")
+		})
+	}
+}
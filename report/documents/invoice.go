@@ -0,0 +1,216 @@
+package documents
+
+import (
+	_ "embed"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed invoice.tmpl.xml
+	invoiceTmpl     string
+	invoiceTemplate = template.Must(template.New("invoice").Funcs(template.FuncMap{
+		"date":    date,
+		"vatRate": vatRateLabel,
+		"inc": func(i int) int {
+			return i + 1
+		},
+		"xml": xmlText,
+	}).Parse(invoiceTmpl))
+)
+
+// Invoice is the sales invoice issued by the company.
+type Invoice struct {
+	Title       string
+	Document    types.Document
+	SaleDate    time.Time
+	Corrected   types.DocumentID
+	Notes       string
+	Seller      types.Contractor
+	Buyer       types.Contractor
+	Lines       []InvoiceLine
+	VATTotals   []InvoiceVATTotal
+	Total       types.Denom
+	Refund      bool
+	Dues        []types.Due
+	BankAccount types.BankAccount
+	Conversion  *InvoiceConversion
+
+	// ExemptionBasis is the legal basis of VAT exemption, set if any line is exempt.
+	ExemptionBasis string
+}
+
+// InvoiceLine is the line of the invoice.
+type InvoiceLine struct {
+	Description string
+	Rate        types.VATRate
+	Net         types.Denom
+	VAT         types.Denom
+	Gross       types.Denom
+}
+
+// InvoiceVATTotal sums lines of the invoice taxed with the VAT rate. VAT in base currency is set for foreign-currency
+// invoices.
+type InvoiceVATTotal struct {
+	Rate    types.VATRate
+	Net     types.Denom
+	VAT     types.Denom
+	Gross   types.Denom
+	BaseVAT types.Denom
+}
+
+// InvoiceConversion presents amounts of foreign-currency invoice in base currency.
+type InvoiceConversion struct {
+	RateDate time.Time
+	Currency types.CurrencySymbol
+	Rate     types.Number
+	Total    types.Denom
+	VAT      types.Denom
+}
+
+type invoiceSource interface {
+	types.SettlementSource
+
+	GetDues() []types.Due
+	GetLines() []types.InvoiceLine
+	OutputVAT(rates types.CurrencyRates) []types.VATAmount
+}
+
+// GenerateInvoices generates invoices for sells in the period having documents issued with automatic numbering.
+func GenerateInvoices(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	seller types.Contractor,
+	bankAccounts []types.BankAccount,
+	exemptionBasis string,
+) []types.ExportDocument {
+	exports := []types.ExportDocument{}
+	for _, source := range issuedInvoices(period, operations) {
+		invoice := newInvoice(source, rates, seller, bankAccounts, exemptionBasis)
+		exports = append(exports, types.ExportDocument{
+			FileName: "Faktura-" + fileNamePart(string(invoice.Document.ID)) + ".fodt",
+			Template: invoiceTemplate,
+			Data:     invoice,
+		})
+	}
+	return exports
+}
+
+func issuedInvoices(period types.Period, operations []types.Operation) []invoiceSource {
+	sources := []invoiceSource{}
+	for _, op := range operations {
+		source, ok := op.(invoiceSource)
+		if ok && source.GetDocument().Series != "" && period.Contains(source.GetDocument().Date) {
+			sources = append(sources, source)
+		}
+	}
+	return sources
+}
+
+func newInvoice(
+	source invoiceSource,
+	rates types.CurrencyRates,
+	seller types.Contractor,
+	bankAccounts []types.BankAccount,
+	exemptionBasis string,
+) *Invoice {
+	invoice := &Invoice{
+		Title:     "FAKTURA",
+		Document:  source.GetDocument(),
+		SaleDate:  source.GetDate(),
+		Corrected: source.GetCorrectedDocument(),
+		Notes:     source.GetNotes(),
+		Seller:    seller,
+		Buyer:     source.GetContractor(),
+		Dues:      source.GetDues(),
+	}
+	if invoice.Corrected != "" {
+		invoice.Title = "FAKTURA KORYGUJĄCA"
+	}
+
+	for _, l := range source.GetLines() {
+		line := InvoiceLine{
+			Description: l.Description,
+			Rate:        l.VATRate,
+			VAT:         types.NewDenom(l.Amount.Currency),
+			Gross:       l.Amount,
+		}
+		switch l.VATRate {
+		case types.VATRateNotRelevant, types.VATRateExempt:
+			invoice.ExemptionBasis = exemptionBasis
+		default:
+			line.VAT = l.VATRate.VAT(l.Amount)
+		}
+		line.Net = line.Gross.Sub(line.VAT)
+		invoice.Lines = append(invoice.Lines, line)
+		invoice.VATTotals = addInvoiceVATTotal(invoice.VATTotals, line)
+
+		if invoice.Total.Currency == "" {
+			invoice.Total = types.NewDenom(l.Amount.Currency)
+		}
+		invoice.Total = invoice.Total.Add(l.Amount)
+	}
+
+	invoice.Refund = invoice.Total.LT(types.NewDenom(invoice.Total.Currency))
+
+	for _, ba := range bankAccounts {
+		if ba.Currency == invoice.Total.Currency {
+			invoice.BankAccount = ba
+			break
+		}
+	}
+
+	if invoice.Total.Currency != types.PLN {
+		rateDate := types.PreviousDay(source.GetDate())
+		total, rate := rates.ToBase(invoice.Total, rateDate)
+		vat := types.BaseZero
+		for _, a := range source.OutputVAT(rates) {
+			vat = vat.Add(a.VAT)
+			for i, t := range invoice.VATTotals {
+				if t.Rate == a.Rate {
+					invoice.VATTotals[i].BaseVAT = a.VAT
+				}
+			}
+		}
+		invoice.Conversion = &InvoiceConversion{
+			RateDate: rateDate,
+			Currency: invoice.Total.Currency,
+			Rate:     rate,
+			Total:    total,
+			VAT:      vat,
+		}
+	}
+
+	return invoice
+}
+
+func addInvoiceVATTotal(totals []InvoiceVATTotal, line InvoiceLine) []InvoiceVATTotal {
+	for i, t := range totals {
+		if t.Rate == line.Rate {
+			totals[i].Net = t.Net.Add(line.Net)
+			totals[i].VAT = t.VAT.Add(line.VAT)
+			totals[i].Gross = t.Gross.Add(line.Gross)
+			return totals
+		}
+	}
+	return append(totals, InvoiceVATTotal{
+		Rate:  line.Rate,
+		Net:   line.Net,
+		VAT:   line.VAT,
+		Gross: line.Gross,
+	})
+}
+
+func vatRateLabel(rate types.VATRate) string {
+	switch rate {
+	case types.VATRateNotRelevant:
+		return "-"
+	case types.VATRateExempt, types.VATRateNotSubject:
+		return string(rate)
+	default:
+		return string(rate) + "%"
+	}
+}
@@ -0,0 +1,156 @@
+package documents
+
+import (
+	_ "embed"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed ksef.tmpl.xml
+	ksefTmpl     string
+	ksefTemplate = template.Must(template.New("ksef").Funcs(template.FuncMap{
+		"date": date,
+		"inc": func(i int) int {
+			return i + 1
+		},
+		"xml": xmlText,
+	}).Parse(ksefTmpl))
+)
+
+// KSeFInvoice is the sales invoice in the structured FA(3) format of KSeF.
+type KSeFInvoice struct {
+	*Invoice
+
+	CreatedAt     time.Time
+	CorrectedDate time.Time
+	BankAccountNo string
+	Lines         []KSeFLine
+	Totals        []KSeFTotal
+}
+
+// KSeFLine is the invoice line with the rate code of KSeF.
+type KSeFLine struct {
+	InvoiceLine
+
+	RateCode string
+}
+
+// KSeFTotal is the sum of invoice lines reported in fields P_13_x and P_14_x of KSeF.
+type KSeFTotal struct {
+	InvoiceVATTotal
+
+	Field  string
+	HasVAT bool
+}
+
+// GenerateKSeFInvoices generates FA(3) XML files of invoices issued with automatic numbering, to be sent to KSeF.
+func GenerateKSeFInvoices(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	createdAt time.Time,
+	seller types.Contractor,
+	bankAccounts []types.BankAccount,
+	exemptionBasis string,
+) []types.ExportDocument {
+	exports := []types.ExportDocument{}
+	for _, source := range issuedInvoices(period, operations) {
+		invoice := &KSeFInvoice{
+			Invoice:   newInvoice(source, rates, seller, bankAccounts, exemptionBasis),
+			CreatedAt: createdAt,
+		}
+		invoice.BankAccountNo = strings.ReplaceAll(invoice.BankAccount.Number, " ", "")
+		if invoice.Corrected != "" {
+			invoice.CorrectedDate = correctedDocumentDate(operations, source)
+		}
+		for _, l := range invoice.Invoice.Lines {
+			invoice.Lines = append(invoice.Lines, KSeFLine{
+				InvoiceLine: l,
+				RateCode:    ksefRateCode(l.Rate),
+			})
+		}
+		for _, rate := range append(types.VATRates, types.VATRateNotRelevant) {
+			for _, t := range invoice.VATTotals {
+				if t.Rate != rate {
+					continue
+				}
+				field, hasVAT := ksefTotalField(t.Rate)
+				invoice.Totals = addKSeFTotal(invoice.Totals, KSeFTotal{
+					InvoiceVATTotal: t,
+					Field:           field,
+					HasVAT:          hasVAT,
+				})
+			}
+		}
+
+		exports = append(exports, types.ExportDocument{
+			FileName: "KSeF-" + fileNamePart(string(invoice.Document.ID)) + ".xml",
+			Template: ksefTemplate,
+			Data:     invoice,
+		})
+	}
+	return exports
+}
+
+// correctedDocumentDate returns issue date of the corrected invoice, or zero time if it is not found.
+func correctedDocumentDate(operations []types.Operation, correction invoiceSource) time.Time {
+	for _, op := range operations {
+		source, ok := op.(invoiceSource)
+		if ok && source.GetDocument().ID == correction.GetCorrectedDocument() &&
+			source.GetContractor().Key() == correction.GetContractor().Key() {
+			return source.GetDocument().Date
+		}
+	}
+	return time.Time{}
+}
+
+// addKSeFTotal merges sums reported in the same field, i.e. exempt lines and lines not subject to VAT settlement.
+func addKSeFTotal(totals []KSeFTotal, total KSeFTotal) []KSeFTotal {
+	for i, t := range totals {
+		if t.Field == total.Field {
+			totals[i].Net = t.Net.Add(total.Net)
+			totals[i].VAT = t.VAT.Add(total.VAT)
+			totals[i].Gross = t.Gross.Add(total.Gross)
+			return totals
+		}
+	}
+	return append(totals, total)
+}
+
+func ksefRateCode(rate types.VATRate) string {
+	switch rate {
+	case types.VATRate23, types.VATRate8, types.VATRate5:
+		return string(rate)
+	case types.VATRate0:
+		return "0 KR"
+	case types.VATRateExempt, types.VATRateNotRelevant:
+		return "zw"
+	case types.VATRateNotSubject:
+		return "np I"
+	default:
+		panic("invalid VAT rate")
+	}
+}
+
+func ksefTotalField(rate types.VATRate) (string, bool) {
+	switch rate {
+	case types.VATRate23:
+		return "1", true
+	case types.VATRate8:
+		return "2", true
+	case types.VATRate5:
+		return "3", true
+	case types.VATRate0:
+		return "6_1", false
+	case types.VATRateExempt, types.VATRateNotRelevant:
+		return "7", false
+	case types.VATRateNotSubject:
+		return "8", false
+	default:
+		panic("invalid VAT rate")
+	}
+}
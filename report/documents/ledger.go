@@ -0,0 +1,125 @@
+package documents
+
+import (
+	_ "embed"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed ledger.tmpl.xml
+	ledgerTmpl     string
+	ledgerTemplate = template.Must(template.New("ledger").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(ledgerTmpl))
+)
+
+// LedgerReport is the general ledger of the accounts.
+type LedgerReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           uint64
+	Accounts       []LedgerAccount
+}
+
+// LedgerAccount is the general ledger of single account.
+type LedgerAccount struct {
+	Account        types.AccountInfo
+	OpeningBalance types.Denom
+	Months         []LedgerMonth
+	Debit          types.Denom
+	Credit         types.Denom
+	ClosingBalance types.Denom
+}
+
+// LedgerMonth groups ledger entries of the month.
+type LedgerMonth struct {
+	Month   string
+	Records []LedgerRecord
+	Debit   types.Denom
+	Credit  types.Denom
+	Balance types.Denom
+}
+
+// LedgerRecord is the entry in the general ledger.
+type LedgerRecord struct {
+	Entry   *types.Entry
+	Debit   types.Denom
+	Credit  types.Denom
+	Balance types.Denom
+}
+
+// GenerateLedgerReport generates general ledger of the selected accounts.
+func GenerateLedgerReport(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+	selection types.LedgerSelection,
+) []types.ReportDocument {
+	accountIDs := selection.Accounts
+	if selection.All {
+		accountIDs = coa.AccountIDs()
+	}
+	if len(accountIDs) == 0 {
+		return nil
+	}
+
+	report := &LedgerReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Year:           uint64(period.Start.Year()),
+		Accounts:       make([]LedgerAccount, 0, len(accountIDs)),
+	}
+
+	for _, accountID := range accountIDs {
+		balance := coa.OpeningBalance(accountID)
+		a := LedgerAccount{
+			Account:        coa.AccountInfo(accountID),
+			OpeningBalance: balance,
+			Debit:          types.BaseZero,
+			Credit:         types.BaseZero,
+		}
+		for _, month := range period.Months() {
+			entries := coa.EntriesMonth(accountID, month)
+			if len(entries) == 0 {
+				continue
+			}
+
+			m := LedgerMonth{
+				Month:   monthName(month.Month()),
+				Records: make([]LedgerRecord, 0, len(entries)),
+				Debit:   types.BaseZero,
+				Credit:  types.BaseZero,
+			}
+			for _, e := range entries {
+				balance = balance.Add(coa.BalanceOf(accountID, e.Amount))
+				m.Records = append(m.Records, LedgerRecord{
+					Entry:   e,
+					Debit:   e.Amount.Debit,
+					Credit:  e.Amount.Credit,
+					Balance: balance,
+				})
+				m.Debit = m.Debit.Add(e.Amount.Debit)
+				m.Credit = m.Credit.Add(e.Amount.Credit)
+			}
+			m.Balance = balance
+
+			a.Months = append(a.Months, m)
+			a.Debit = a.Debit.Add(m.Debit)
+			a.Credit = a.Credit.Add(m.Credit)
+		}
+		a.ClosingBalance = balance
+
+		report.Accounts = append(report.Accounts, a)
+	}
+
+	return []types.ReportDocument{{
+		Template: ledgerTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Księga główna",
+			LockedRows: 4,
+		},
+	}}
+}
@@ -0,0 +1,146 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func TestLedgerReport(t *testing.T) {
+	t.Parallel()
+
+	coa := smallChart()
+	book(coa, day(2025, time.January, 10),
+		types.NewEntryRecord(materialsAccount, types.DebitBalance(pln("100.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("100.00"))),
+	)
+	book(coa, day(2025, time.January, 20),
+		types.NewEntryRecord(bankAccount, types.DebitBalance(pln("30.00"))),
+		types.NewEntryRecord(fundAccount, types.CreditBalance(pln("30.00"))),
+	)
+	book(coa, day(2025, time.March, 3),
+		types.NewEntryRecord(servicesAccount, types.DebitBalance(pln("50.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("50.00"))),
+	)
+
+	type month struct {
+		name     string
+		balances []string
+		debit    string
+		credit   string
+	}
+
+	type account struct {
+		accountID types.AccountID
+		opening   string
+		months    []month
+		debit     string
+		credit    string
+		closing   string
+	}
+
+	bank := account{
+		accountID: bankAccount,
+		opening:   "1000.00",
+		months: []month{
+			{name: "styczeń", balances: []string{"900.00", "930.00"}, debit: "30.00", credit: "100.00"},
+			{name: "marzec", balances: []string{"880.00"}, debit: "0", credit: "50.00"},
+		},
+		debit:   "30.00",
+		credit:  "150.00",
+		closing: "880.00",
+	}
+	fund := account{
+		accountID: fundAccount,
+		opening:   "1000.00",
+		months: []month{
+			{name: "styczeń", balances: []string{"1030.00"}, debit: "0", credit: "30.00"},
+		},
+		debit:   "0",
+		credit:  "30.00",
+		closing: "1030.00",
+	}
+	costs := account{
+		accountID: costsAccount,
+		opening:   "0",
+		months: []month{
+			{name: "styczeń", balances: []string{"100.00"}, debit: "100.00", credit: "0"},
+			{name: "marzec", balances: []string{"150.00"}, debit: "50.00", credit: "0"},
+		},
+		debit:   "150.00",
+		credit:  "0",
+		closing: "150.00",
+	}
+
+	tests := []struct {
+		name      string
+		selection types.LedgerSelection
+		accounts  []account
+		all       int
+	}{
+		{
+			name: "nothing selected",
+		},
+		{
+			name:      "selected accounts",
+			selection: types.LedgerSelection{Accounts: []types.AccountID{fundAccount, bankAccount, costsAccount}},
+			accounts:  []account{fund, bank, costs},
+		},
+		{
+			name:      "all accounts",
+			selection: types.LedgerSelection{All: true},
+			accounts:  []account{bank, costs},
+			all:       6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			docs := documents.GenerateLedgerReport(firstQuarter, coa, "", "", tt.selection)
+			if len(tt.accounts) == 0 {
+				if len(docs) != 0 {
+					t.Fatalf("expected no ledger, got %d", len(docs))
+				}
+				return
+			}
+
+			report := docs[0].Data.(*documents.LedgerReport)
+			expectedAccounts := len(tt.accounts)
+			if tt.all != 0 {
+				expectedAccounts = tt.all
+			}
+			if len(report.Accounts) != expectedAccounts {
+				t.Fatalf("expected %d accounts, got %d", expectedAccounts, len(report.Accounts))
+			}
+			for i, e := range tt.accounts {
+				a := report.Accounts[i]
+				if a.Account.ID.String() != e.accountID.String() {
+					t.Fatalf("account %d: expected %s, got %s", i, e.accountID, a.Account.ID)
+				}
+				assertDenom(t, a.Account.Name+" opening", e.opening, a.OpeningBalance)
+				assertDenom(t, a.Account.Name+" debit", e.debit, a.Debit)
+				assertDenom(t, a.Account.Name+" credit", e.credit, a.Credit)
+				assertDenom(t, a.Account.Name+" closing", e.closing, a.ClosingBalance)
+				if len(a.Months) != len(e.months) {
+					t.Fatalf("%s: expected %d months, got %d", a.Account.Name, len(e.months), len(a.Months))
+				}
+				for j, em := range e.months {
+					m := a.Months[j]
+					if m.Month != em.name || len(m.Records) != len(em.balances) {
+						t.Fatalf("%s month %d: expected %s with %d records, got %s with %d", a.Account.Name, j,
+							em.name, len(em.balances), m.Month, len(m.Records))
+					}
+					for k, b := range em.balances {
+						assertDenom(t, a.Account.Name+" "+m.Month+" balance", b, m.Records[k].Balance)
+					}
+					assertDenom(t, a.Account.Name+" "+m.Month+" debit", em.debit, m.Debit)
+					assertDenom(t, a.Account.Name+" "+m.Month+" credit", em.credit, m.Credit)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package documents
+
+import (
+	_ "embed"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed openitems.tmpl.xml
+	openItemsTmpl     string
+	openItemsTemplate = template.Must(template.New("openItems").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(openItemsTmpl))
+)
+
+// OpenItemsReport lists receivables and payables not settled as of the date.
+type OpenItemsReport struct {
+	SheetName      string
+	CompanyName    string
+	CompanyAddress string
+	Date           time.Time
+	Items          []types.OpenItem
+	Receivables    types.Denom
+	Payables       types.Denom
+}
+
+// GenerateOpenItemsReport generates the list of open items of all the contractors as of the date.
+func GenerateOpenItemsReport(
+	ledgers []*types.ContractorLedger,
+	companyName, companyAddress string,
+	date time.Time,
+	sheetName string,
+) types.ReportDocument {
+	report := &OpenItemsReport{
+		SheetName:      sheetName,
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Date:           date,
+		Receivables:    types.BaseZero,
+		Payables:       types.BaseZero,
+	}
+	for _, l := range ledgers {
+		for _, item := range l.OpenItems(date) {
+			report.Items = append(report.Items, item)
+			if item.IsReceivable() {
+				report.Receivables = report.Receivables.Add(item.BaseRemaining)
+			} else {
+				report.Payables = report.Payables.Add(item.BaseRemaining.Neg())
+			}
+		}
+	}
+	sort.SliceStable(report.Items, func(i, j int) bool {
+		return strings.Compare(report.Items[i].Contractor.Name, report.Items[j].Contractor.Name) < 0
+	})
+
+	return types.ReportDocument{
+		Template: openItemsTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       sheetName,
+			LockedRows: 4,
+		},
+	}
+}
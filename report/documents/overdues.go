@@ -2,7 +2,9 @@ package documents
 
 import (
 	_ "embed"
+	"math"
 	"sort"
+	"strings"
 	"text/template"
 	"time"
 
@@ -12,85 +14,285 @@ import (
 var (
 	//go:embed overdues.tmpl.xml
 	overDuesTmpl     string
-	overDuesTemplate = template.Must(template.New("overDues").Parse(overDuesTmpl))
+	overDuesTemplate = template.Must(template.New("overDues").Funcs(template.FuncMap{
+		"date":       date,
+		"bucketName": agingBucketName,
+	}).Parse(overDuesTmpl))
 )
 
-// OverDueRecord represents VAT over due.
+// AgingBucket is the range of days after the due date.
+type AgingBucket int
+
+// Aging buckets.
+const (
+	AgingNotDue AgingBucket = iota
+	Aging1To30
+	Aging31To60
+	Aging61To90
+	AgingOver90
+	agingBucketCount
+)
+
+// OverDueReport lists unpaid dues as of the date.
+type OverDueReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Date           time.Time
+	Records        []OverDueRecord
+	Summaries      []OverDueSummary
+}
+
+// OverDueRecord represents unpaid due.
 type OverDueRecord struct {
 	DueDate    time.Time
 	Document   types.Document
 	Contractor types.Contractor
+	Payable    bool
 	Amount     types.Denom
+	BaseAmount types.Denom
+	Days       int64
+	Bucket     AgingBucket
+}
+
+// OverDueSummary sums unpaid dues of the contractor in the currency by aging buckets.
+type OverDueSummary struct {
+	Contractor types.Contractor
+	Payable    bool
+	Currency   types.CurrencySymbol
+	Buckets    []types.Denom
+	Total      types.Denom
+	BaseTotal  types.Denom
 }
 
 type overDueSource interface {
-	GetDocument() types.Document
-	GetContractor() types.Contractor
+	types.SettlementSource
+
 	GetDues() []types.Due
 	GetPayments() []types.Payment
 }
 
-// GenerateOverDueReport generates over due report.
-func GenerateOverDueReport(
-	period types.Period,
-	operations []types.Operation,
-) types.ReportDocument {
-	report := []OverDueRecord{}
+type overDueGroup struct {
+	document   types.Document
+	contractor types.Contractor
+	payable    bool
+	dues       []types.Due
+	settled    map[types.CurrencySymbol]types.Denom
+}
 
+// OverDues returns dues of sells and purchases which are not paid as of the date, including the ones which are not
+// due yet. Payments and credit notes are settled against the earliest dues of the corrected document.
+func OverDues(date time.Time, operations []types.Operation) []OverDueRecord {
+	groups := map[string]*overDueGroup{}
+	order := []string{}
 	for _, op := range operations {
-		overDueSource, ok := op.(overDueSource)
-		if !ok {
+		source, ok := op.(overDueSource)
+		if !ok || source.GetDate().After(date) {
 			continue
 		}
 
-		paid := map[types.CurrencySymbol]types.Denom{}
-		for _, p := range overDueSource.GetPayments() {
-			paidCurrency, exists := paid[p.Amount.Currency]
-			if !exists {
-				paidCurrency = types.NewDenom(p.Amount.Currency)
+		document := source.GetDocument()
+		corrected := source.GetCorrectedDocument()
+		settledID := document.ID
+		if corrected != "" {
+			settledID = corrected
+		}
+
+		payable := isPayable(source)
+		key := source.GetContractor().Key() + "/" + string(settledID)
+		if payable {
+			key += "/payable"
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &overDueGroup{
+				document: types.Document{
+					ID: settledID,
+				},
+				contractor: source.GetContractor(),
+				payable:    payable,
+				settled:    map[types.CurrencySymbol]types.Denom{},
+			}
+			groups[key] = group
+			order = append(order, key)
+		}
+
+		if corrected == "" {
+			group.document = document
+			group.dues = append(group.dues, source.GetDues()...)
+		} else {
+			for _, d := range source.GetDues() {
+				group.settle(d.Amount.Neg())
+			}
+		}
+		for _, p := range source.GetPayments() {
+			if !p.Date.After(date) {
+				group.settle(p.Amount)
 			}
-			paid[p.Amount.Currency] = paidCurrency.Add(p.Amount)
 		}
+	}
 
-		dues := overDueSource.GetDues()
-		sort.Slice(dues, func(i, j int) bool {
+	day := truncateDay(date)
+	records := []OverDueRecord{}
+	for _, key := range order {
+		group := groups[key]
+
+		dues := group.dues
+		sort.SliceStable(dues, func(i, j int) bool {
 			return dues[i].Date.Before(dues[j].Date)
 		})
 
 		for _, d := range dues {
-			if !period.Contains(d.Date) {
-				break
-			}
-
-			p, exists := paid[d.Amount.Currency]
+			p, exists := group.settled[d.Amount.Currency]
 			switch {
 			case !exists:
 			case p.GT(d.Amount):
-				paid[d.Amount.Currency] = p.Sub(d.Amount)
+				group.settled[d.Amount.Currency] = p.Sub(d.Amount)
 				continue
 			case p.EQ(d.Amount):
-				delete(paid, d.Amount.Currency)
+				delete(group.settled, d.Amount.Currency)
 				continue
 			default:
 				d.Amount = d.Amount.Sub(p)
-				delete(paid, d.Amount.Currency)
+				delete(group.settled, d.Amount.Currency)
 			}
 
-			report = append(report, OverDueRecord{
+			days := int64(math.Round(day.Sub(truncateDay(d.Date)).Hours() / 24))
+			records = append(records, OverDueRecord{
 				DueDate:    d.Date,
-				Document:   overDueSource.GetDocument(),
-				Contractor: overDueSource.GetContractor(),
+				Document:   group.document,
+				Contractor: group.contractor,
+				Payable:    group.payable,
 				Amount:     d.Amount,
+				Days:       days,
+				Bucket:     agingBucket(days),
 			})
 		}
 	}
 
+	return records
+}
+
+// GenerateOverDueReport generates over due report.
+func GenerateOverDueReport(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	report := &OverDueReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Date:           period.End,
+		Records:        OverDues(period.End, operations),
+	}
+	for i, r := range report.Records {
+		report.Records[i].BaseAmount, _ = rates.ToBaseLatest(r.Amount, period.End)
+	}
+
+	summaries := map[string]*OverDueSummary{}
+	for _, r := range report.Records {
+		key := r.Contractor.Key() + "/" + string(r.Amount.Currency)
+		if r.Payable {
+			key += "/payable"
+		}
+		summary, exists := summaries[key]
+		if !exists {
+			summary = &OverDueSummary{
+				Contractor: r.Contractor,
+				Payable:    r.Payable,
+				Currency:   r.Amount.Currency,
+				Buckets:    make([]types.Denom, 0, agingBucketCount),
+				Total:      types.NewDenom(r.Amount.Currency),
+				BaseTotal:  types.BaseZero,
+			}
+			for range agingBucketCount {
+				summary.Buckets = append(summary.Buckets, types.NewDenom(r.Amount.Currency))
+			}
+			summaries[key] = summary
+		}
+		summary.Buckets[r.Bucket] = summary.Buckets[r.Bucket].Add(r.Amount)
+		summary.Total = summary.Total.Add(r.Amount)
+		summary.BaseTotal = summary.BaseTotal.Add(r.BaseAmount)
+	}
+	for _, s := range summaries {
+		report.Summaries = append(report.Summaries, *s)
+	}
+	sort.Slice(report.Summaries, func(i, j int) bool {
+		s1 := report.Summaries[i]
+		s2 := report.Summaries[j]
+		if s1.Payable != s2.Payable {
+			return !s1.Payable
+		}
+		if c := strings.Compare(s1.Contractor.Name, s2.Contractor.Name); c != 0 {
+			return c < 0
+		}
+		if c := strings.Compare(s1.Contractor.Key(), s2.Contractor.Key()); c != 0 {
+			return c < 0
+		}
+		return strings.Compare(string(s1.Currency), string(s2.Currency)) < 0
+	})
+
 	return types.ReportDocument{
 		Template: overDuesTemplate,
 		Data:     report,
 		Config: types.SheetConfig{
 			Name:       "Zaległości",
-			LockedRows: 1,
+			LockedRows: 4,
 		},
 	}
 }
+
+func (g *overDueGroup) settle(amount types.Denom) {
+	settled, exists := g.settled[amount.Currency]
+	if !exists {
+		settled = types.NewDenom(amount.Currency)
+	}
+	g.settled[amount.Currency] = settled.Add(amount)
+}
+
+// isPayable returns true if the document creates liability. Credit notes have the opposite sign of dues than the
+// documents they correct.
+func isPayable(source types.SettlementSource) bool {
+	dues := source.SettlementDues()
+	sum := types.NewDenom(dues[0].Amount.Currency)
+	for _, d := range dues {
+		sum = sum.Add(d.Amount)
+	}
+	return sum.LT(types.NewDenom(sum.Currency)) != (source.GetCorrectedDocument() != "")
+}
+
+func agingBucket(days int64) AgingBucket {
+	switch {
+	case days <= 0:
+		return AgingNotDue
+	case days <= 30:
+		return Aging1To30
+	case days <= 60:
+		return Aging31To60
+	case days <= 90:
+		return Aging61To90
+	default:
+		return AgingOver90
+	}
+}
+
+func agingBucketName(bucket AgingBucket) string {
+	switch bucket {
+	case AgingNotDue:
+		return "nieprzeterminowane"
+	case Aging1To30:
+		return "1-30 dni"
+	case Aging31To60:
+		return "31-60 dni"
+	case Aging61To90:
+		return "61-90 dni"
+	case AgingOver90:
+		return "powyżej 90 dni"
+	default:
+		panic("invalid aging bucket")
+	}
+}
+
+func truncateDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,173 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+func creditNote(date time.Time, id, corrected types.DocumentID, amount string) *operations.Sell {
+	note := receivable(date, id, dues(date, amount))
+	note.CorrectedDocument = corrected
+	return note
+}
+
+func TestOverDues(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		document types.DocumentID
+		payable  bool
+		amount   string
+		days     int64
+		bucket   documents.AgingBucket
+	}
+
+	jan10 := day(2025, time.January, 10)
+
+	tests := []struct {
+		name       string
+		operations []types.Operation
+		records    []record
+	}{
+		{
+			name: "not paid",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", dues(jan10, "100.00")),
+			},
+			records: []record{
+				{document: "FS/1", amount: "100.00", days: 80, bucket: documents.Aging61To90},
+			},
+		},
+		{
+			name: "not due yet",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", dues(day(2025, time.April, 15), "100.00")),
+			},
+			records: []record{
+				{document: "FS/1", amount: "100.00", days: -15, bucket: documents.AgingNotDue},
+			},
+		},
+		{
+			name: "paid",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", dues(jan10, "100.00"), paid(day(2025, time.February, 1), "100.00")),
+			},
+		},
+		{
+			name: "payment settles the earliest due",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", []types.Due{
+					{Date: day(2025, time.March, 10), Amount: pln("100.00")},
+					{Date: day(2025, time.January, 1), Amount: pln("100.00")},
+				}, paid(day(2025, time.February, 1), "150.00")),
+			},
+			records: []record{
+				{document: "FS/1", amount: "50.00", days: 21, bucket: documents.Aging1To30},
+			},
+		},
+		{
+			name: "payment after the date ignored",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", dues(day(2025, time.February, 20), "100.00"),
+					paid(day(2025, time.April, 1), "100.00")),
+			},
+			records: []record{
+				{document: "FS/1", amount: "100.00", days: 39, bucket: documents.Aging31To60},
+			},
+		},
+		{
+			name: "credit note",
+			operations: []types.Operation{
+				receivable(jan10, "FS/1", dues(day(2024, time.December, 1), "100.00")),
+				creditNote(day(2025, time.February, 1), "KFS/1", "FS/1", "-30.00"),
+			},
+			records: []record{
+				{document: "FS/1", amount: "70.00", days: 120, bucket: documents.AgingOver90},
+			},
+		},
+		{
+			name: "document after the date ignored",
+			operations: []types.Operation{
+				receivable(day(2025, time.April, 1), "FS/1", dues(day(2025, time.April, 1), "100.00")),
+			},
+		},
+		{
+			name: "sell and purchase with the same document ID",
+			operations: []types.Operation{
+				receivable(jan10, "1/2025", dues(jan10, "100.00")),
+				payable(jan10, day(2025, time.March, 1), "1/2025", "40.00"),
+			},
+			records: []record{
+				{document: "1/2025", amount: "100.00", days: 80, bucket: documents.Aging61To90},
+				{document: "1/2025", payable: true, amount: "40.00", days: 30, bucket: documents.Aging1To30},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			records := documents.OverDues(firstQuarter.End, tt.operations)
+			if len(records) != len(tt.records) {
+				t.Fatalf("expected %d records, got %d", len(tt.records), len(records))
+			}
+			for i, e := range tt.records {
+				r := records[i]
+				if r.Document.ID != e.document || r.Payable != e.payable || r.Days != e.days || r.Bucket != e.bucket {
+					t.Errorf("record %d: expected %s payable %t %d days bucket %d, got %s payable %t %d days bucket %d",
+						i, e.document, e.payable, e.days, e.bucket, r.Document.ID, r.Payable, r.Days, r.Bucket)
+				}
+				assertDenom(t, "amount", e.amount, r.Amount)
+			}
+		})
+	}
+}
+
+func TestOverDueReportSummaries(t *testing.T) {
+	t.Parallel()
+
+	other := types.Contractor{Name: "Bosman", TaxID: "5270000000"}
+	jan10 := day(2025, time.January, 10)
+	otherReceivable := receivable(jan10, "FS/3", dues(day(2025, time.March, 20), "20.00"))
+	otherReceivable.Contractor = other
+
+	ops := []types.Operation{
+		payable(jan10, jan10, "FZ/1", "40.00"),
+		receivable(jan10, "FS/1", dues(jan10, "100.00")),
+		receivable(jan10, "FS/2", dues(day(2025, time.April, 10), "30.00")),
+		otherReceivable,
+	}
+
+	expected := []struct {
+		contractor types.Contractor
+		payable    bool
+		buckets    []string
+		total      string
+	}{
+		{contractor: other, buckets: []string{"0", "20.00", "0", "0", "0"}, total: "20.00"},
+		{contractor: contractor, buckets: []string{"30.00", "0", "0", "100.00", "0"}, total: "130.00"},
+		{contractor: contractor, payable: true, buckets: []string{"0", "0", "0", "40.00", "0"}, total: "40.00"},
+	}
+
+	report := documents.GenerateOverDueReport(firstQuarter, ops, nil, "", "").Data.(*documents.OverDueReport)
+	if len(report.Summaries) != len(expected) {
+		t.Fatalf("expected %d summaries, got %d", len(expected), len(report.Summaries))
+	}
+	for i, e := range expected {
+		s := report.Summaries[i]
+		if s.Contractor.Name != e.contractor.Name || s.Payable != e.payable {
+			t.Errorf("summary %d: expected %s payable %t, got %s payable %t", i, e.contractor.Name, e.payable,
+				s.Contractor.Name, s.Payable)
+		}
+		for j, b := range e.buckets {
+			assertDenom(t, s.Contractor.Name+" bucket", b, s.Buckets[j])
+		}
+		assertDenom(t, s.Contractor.Name+" total", e.total, s.Total)
+		assertDenom(t, s.Contractor.Name+" base total", e.total, s.BaseTotal)
+	}
+}
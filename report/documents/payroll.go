@@ -0,0 +1,341 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed pit4r.tmpl.xml
+	pit4rTmpl     string
+	pit4rTemplate = template.Must(template.New("pit4r").Parse(pit4rTmpl))
+
+	//go:embed pit11.tmpl.xml
+	pit11Tmpl     string
+	pit11Template = template.Must(template.New("pit11").Parse(pit11Tmpl))
+
+	//go:embed pit4rdeclaration.tmpl.xml
+	pit4rDeclarationTmpl     string
+	pit4rDeclarationTemplate = template.Must(template.New("pit4rDeclaration").Funcs(template.FuncMap{
+		"units": units,
+		"xml":   xmlText,
+	}).Parse(pit4rDeclarationTmpl))
+
+	//go:embed pit11declaration.tmpl.xml
+	pit11DeclarationTmpl     string
+	pit11DeclarationTemplate = template.Must(template.New("pit11Declaration").Funcs(template.FuncMap{
+		"date":  date,
+		"units": units,
+		"xml":   xmlText,
+	}).Parse(pit11DeclarationTmpl))
+)
+
+// PIT4RReport is the yearly summary of tax advances withheld by the payer.
+type PIT4RReport struct {
+	CompanyName    string
+	CompanyAddress string
+	CompanyTaxID   string
+	TaxOfficeCode  string
+	Year           uint64
+	Months         []PIT4RMonth
+	Summary        PIT4RMonth
+}
+
+// NewPIT4RMonth creates new PIT-4R month.
+func NewPIT4RMonth(month uint64, monthName string) PIT4RMonth {
+	return PIT4RMonth{
+		Month:      month,
+		MonthName:  monthName,
+		Gross:      types.BaseZero,
+		TaxAdvance: types.BaseZero,
+	}
+}
+
+// PIT4RMonth is the monthly summary of tax advances.
+type PIT4RMonth struct {
+	Month      uint64
+	MonthName  string
+	Contracts  uint64
+	Gross      types.Denom
+	TaxAdvance types.Denom
+}
+
+// AddWithholding adds withholding to the summary.
+func (m PIT4RMonth) AddWithholding(w Withholding) PIT4RMonth {
+	m.Contracts++
+	m.Gross = m.Gross.Add(w.Payroll.Gross)
+	m.TaxAdvance = m.TaxAdvance.Add(w.TaxAdvance)
+	return m
+}
+
+// AddMonth adds month to the summary.
+func (m PIT4RMonth) AddMonth(m2 PIT4RMonth) PIT4RMonth {
+	m.Contracts += m2.Contracts
+	m.Gross = m.Gross.Add(m2.Gross)
+	m.TaxAdvance = m.TaxAdvance.Add(m2.TaxAdvance)
+	return m
+}
+
+// PIT11Report is the yearly summary of incomes and tax advances of each employee.
+type PIT11Report struct {
+	CompanyName    string
+	CompanyAddress string
+	CompanyTaxID   string
+	TaxOfficeCode  string
+	Year           uint64
+	Records        []PIT11Record
+}
+
+// NewPIT11Record creates new PIT-11 record.
+func NewPIT11Record(employee types.Employee) PIT11Record {
+	return PIT11Record{
+		Employee:           employee,
+		Gross:              types.BaseZero,
+		TaxDeductibleCosts: types.BaseZero,
+		Income:             types.BaseZero,
+		SocialEmployee:     types.BaseZero,
+		Health:             types.BaseZero,
+		TaxAdvance:         types.BaseZero,
+	}
+}
+
+// PIT11Record is the yearly summary of single employee.
+type PIT11Record struct {
+	Index              uint64
+	Employee           types.Employee
+	Gross              types.Denom
+	TaxDeductibleCosts types.Denom
+	Income             types.Denom
+	SocialEmployee     types.Denom
+	Health             types.Denom
+	TaxAdvance         types.Denom
+}
+
+// AddWithholding adds withholding to the record.
+func (r PIT11Record) AddWithholding(w Withholding) PIT11Record {
+	r.Gross = r.Gross.Add(w.Payroll.Gross)
+	r.TaxDeductibleCosts = r.TaxDeductibleCosts.Add(w.Payroll.TaxDeductibleCosts)
+	r.Income = r.Income.Add(w.Payroll.Gross.Sub(w.Payroll.TaxDeductibleCosts))
+	r.SocialEmployee = r.SocialEmployee.Add(w.Payroll.SocialEmployee)
+	r.Health = r.Health.Add(w.Payroll.Health)
+	r.TaxAdvance = r.TaxAdvance.Add(w.TaxAdvance)
+	return r
+}
+
+// PIT4RDeclaration is the PIT-4R declaration of the payer.
+type PIT4RDeclaration struct {
+	CompanyName   string
+	CompanyTaxID  string
+	TaxOfficeCode string
+	Year          uint64
+	Fields        []DeclarationField
+}
+
+// PIT11Declaration is the PIT-11 declaration issued to single employee.
+type PIT11Declaration struct {
+	CompanyName   string
+	CompanyTaxID  string
+	TaxOfficeCode string
+	Year          uint64
+	Record        PIT11Record
+}
+
+// DeclarationField is the position of the declaration.
+type DeclarationField struct {
+	Name  string
+	Value types.Denom
+}
+
+// Withholding is the payroll together with the tax advance withheld.
+type Withholding struct {
+	Payroll    types.Payroll
+	TaxAdvance types.Denom
+}
+
+type payrollSource interface {
+	GetPayroll() types.Payroll
+}
+
+// GeneratePIT4RReport generates the data required by PIT-4R declaration.
+func GeneratePIT4RReport(
+	period types.Period,
+	operations []types.Operation,
+	companyName, companyAddress, companyTaxID, taxOfficeCode string,
+) types.ReportDocument {
+	return types.ReportDocument{
+		Template: pit4rTemplate,
+		Data:     newPIT4RReport(period, operations, companyName, companyAddress, companyTaxID, taxOfficeCode),
+		Config: types.SheetConfig{
+			Name:       "PIT-4R",
+			LockedRows: 4,
+		},
+	}
+}
+
+// GeneratePIT11Report generates the data required by PIT-11 declarations.
+func GeneratePIT11Report(
+	period types.Period,
+	operations []types.Operation,
+	companyName, companyAddress, companyTaxID, taxOfficeCode string,
+) types.ReportDocument {
+	return types.ReportDocument{
+		Template: pit11Template,
+		Data:     newPIT11Report(period, operations, companyName, companyAddress, companyTaxID, taxOfficeCode),
+		Config: types.SheetConfig{
+			Name:       "PIT-11",
+			LockedRows: 4,
+		},
+	}
+}
+
+// GeneratePayrollDeclarations generates PIT-4R(12) and PIT-11(29) declarations in the XML format of e-Deklaracje.
+// PIT-11 reports incomes in the row of activities performed personally (P_37-P_40) and contributions deducted by
+// the payer in P_79 and P_81.
+func GeneratePayrollDeclarations(
+	period types.Period,
+	operations []types.Operation,
+	companyName, companyTaxID, taxOfficeCode string,
+) []types.ExportDocument {
+	pit4r := newPIT4RReport(period, operations, companyName, "", companyTaxID, taxOfficeCode)
+	if pit4r.Summary.Contracts == 0 {
+		return nil
+	}
+	if taxOfficeCode == "" {
+		panic("tax office code is not set")
+	}
+
+	docs := []types.ExportDocument{
+		{
+			FileName: fmt.Sprintf("PIT-4R-%d.xml", pit4r.Year),
+			Template: pit4rDeclarationTemplate,
+			Data: &PIT4RDeclaration{
+				CompanyName:   companyName,
+				CompanyTaxID:  companyTaxID,
+				TaxOfficeCode: taxOfficeCode,
+				Year:          pit4r.Year,
+				Fields:        pit4rFields(pit4r),
+			},
+		},
+	}
+
+	pit11 := newPIT11Report(period, operations, companyName, "", companyTaxID, taxOfficeCode)
+	for _, r := range pit11.Records {
+		docs = append(docs, types.ExportDocument{
+			FileName: fmt.Sprintf("PIT-11-%d-%s.xml", pit11.Year, r.Employee.PESEL),
+			Template: pit11DeclarationTemplate,
+			Data: &PIT11Declaration{
+				CompanyName:   companyName,
+				CompanyTaxID:  companyTaxID,
+				TaxOfficeCode: taxOfficeCode,
+				Year:          pit11.Year,
+				Record:        r,
+			},
+		})
+	}
+
+	return docs
+}
+
+// pit4rFields returns positions of section D of PIT-4R(12). Advances withheld from incomes of activities performed
+// personally (art. 41 ust. 1) in months 1-12 are stored in P_8-P_19, amounts due to the tax office in P_20-P_31.
+func pit4rFields(report *PIT4RReport) []DeclarationField {
+	fields := make([]DeclarationField, 0, 2*len(report.Months))
+	for _, m := range report.Months {
+		fields = append(fields, DeclarationField{
+			Name:  fmt.Sprintf("P_%d", 7+m.Month),
+			Value: m.TaxAdvance,
+		})
+	}
+	for _, m := range report.Months {
+		fields = append(fields, DeclarationField{
+			Name:  fmt.Sprintf("P_%d", 19+m.Month),
+			Value: m.TaxAdvance,
+		})
+	}
+	return fields
+}
+
+func newPIT4RReport(
+	period types.Period,
+	operations []types.Operation,
+	companyName, companyAddress, companyTaxID, taxOfficeCode string,
+) *PIT4RReport {
+	report := &PIT4RReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		CompanyTaxID:   companyTaxID,
+		TaxOfficeCode:  taxOfficeCode,
+		Year:           uint64(period.Start.Year()),
+		Summary:        NewPIT4RMonth(0, ""),
+	}
+
+	ws := withholdings(period, operations)
+	for _, month := range period.Months() {
+		m := NewPIT4RMonth(uint64(month.Month()), monthName(month.Month()))
+		for _, w := range ws {
+			if w.Payroll.WithholdingDate.Month() == month.Month() {
+				m = m.AddWithholding(w)
+			}
+		}
+		report.Months = append(report.Months, m)
+		report.Summary = report.Summary.AddMonth(m)
+	}
+
+	return report
+}
+
+func newPIT11Report(
+	period types.Period,
+	operations []types.Operation,
+	companyName, companyAddress, companyTaxID, taxOfficeCode string,
+) *PIT11Report {
+	report := &PIT11Report{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		CompanyTaxID:   companyTaxID,
+		TaxOfficeCode:  taxOfficeCode,
+		Year:           uint64(period.Start.Year()),
+	}
+
+	indexes := map[types.Employee]int{}
+	for _, w := range withholdings(period, operations) {
+		i, exists := indexes[w.Payroll.Employee]
+		if !exists {
+			i = len(report.Records)
+			indexes[w.Payroll.Employee] = i
+			report.Records = append(report.Records, NewPIT11Record(w.Payroll.Employee))
+			report.Records[i].Index = uint64(i + 1)
+		}
+		report.Records[i] = report.Records[i].AddWithholding(w)
+	}
+
+	return report
+}
+
+// withholdings returns payrolls paid in the period. Tax advances are reported in the year and month of the payment,
+// so contracts of the previous year paid in the period are included.
+func withholdings(period types.Period, operations []types.Operation) []Withholding {
+	result := []Withholding{}
+	for _, op := range operations {
+		payrollSource, ok := op.(payrollSource)
+		if !ok {
+			continue
+		}
+		payroll := payrollSource.GetPayroll()
+		if payroll.WithholdingDate.IsZero() || !period.Contains(payroll.WithholdingDate) {
+			continue
+		}
+		result = append(result, Withholding{
+			Payroll:    payroll,
+			TaxAdvance: payroll.TaxAdvance,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Payroll.WithholdingDate.Before(result[j].Payroll.WithholdingDate)
+	})
+	return result
+}
@@ -0,0 +1,164 @@
+package documents
+
+import (
+	_ "embed"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed project.tmpl.xml
+	projectTmpl     string
+	projectTemplate = template.Must(template.New("project").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(projectTmpl))
+)
+
+// ProjectReport is the report of incomes and costs per project.
+type ProjectReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Projects       []ProjectSection
+}
+
+// ProjectSection is the section of the report related to single project.
+type ProjectSection struct {
+	Project types.Project
+	Name    string
+	Records []ProjectRecord
+	Summary ProjectSummary
+}
+
+// ProjectRecord is the entry related to the project.
+type ProjectRecord struct {
+	Index        uint64
+	Date         time.Time
+	Document     types.Document
+	Contractor   types.Contractor
+	Notes        string
+	IncomePaid   types.Denom
+	IncomeFree   types.Denom
+	IncomeOther  types.Denom
+	CostPaid     types.Denom
+	CostFree     types.Denom
+	CostTaxed    types.Denom
+	CostNotTaxed types.Denom
+}
+
+// NewProjectSummary creates new project summary.
+func NewProjectSummary() ProjectSummary {
+	return ProjectSummary{
+		IncomePaid:   types.BaseZero,
+		IncomeFree:   types.BaseZero,
+		IncomeOther:  types.BaseZero,
+		CostPaid:     types.BaseZero,
+		CostFree:     types.BaseZero,
+		CostTaxed:    types.BaseZero,
+		CostNotTaxed: types.BaseZero,
+	}
+}
+
+// ProjectSummary is the summary of the project.
+type ProjectSummary struct {
+	IncomePaid   types.Denom
+	IncomeFree   types.Denom
+	IncomeOther  types.Denom
+	CostPaid     types.Denom
+	CostFree     types.Denom
+	CostTaxed    types.Denom
+	CostNotTaxed types.Denom
+}
+
+// AddRecord adds record to the summary.
+func (ps ProjectSummary) AddRecord(r ProjectRecord) ProjectSummary {
+	ps.IncomePaid = ps.IncomePaid.Add(r.IncomePaid)
+	ps.IncomeFree = ps.IncomeFree.Add(r.IncomeFree)
+	ps.IncomeOther = ps.IncomeOther.Add(r.IncomeOther)
+	ps.CostPaid = ps.CostPaid.Add(r.CostPaid)
+	ps.CostFree = ps.CostFree.Add(r.CostFree)
+	ps.CostTaxed = ps.CostTaxed.Add(r.CostTaxed)
+	ps.CostNotTaxed = ps.CostNotTaxed.Add(r.CostNotTaxed)
+	return ps
+}
+
+type projectSource interface {
+	GetProject() types.Project
+}
+
+// GenerateProjectReport generates the report of incomes and costs per project.
+func GenerateProjectReport(
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	sections := map[types.Project]*ProjectSection{}
+	for _, e := range coa.Entries(types.NewAccountID(accounts.PiK)) {
+		var project types.Project
+		if ps, ok := e.Data.(projectSource); ok {
+			project = ps.GetProject()
+		}
+
+		section, exists := sections[project]
+		if !exists {
+			name := string(project)
+			if name == "" {
+				name = "Bez projektu"
+			}
+			section = &ProjectSection{
+				Project: project,
+				Name:    name,
+				Summary: NewProjectSummary(),
+			}
+			sections[project] = section
+		}
+
+		r := ProjectRecord{
+			Index:      uint64(len(section.Records) + 1),
+			Date:       e.GetDate(),
+			Document:   e.GetDocument(),
+			Contractor: e.GetContractor(),
+			Notes:      e.GetNotes(),
+			IncomePaid: coa.Amount(types.NewAccountID(accounts.Odplatna), e.ID).Credit,
+			IncomeFree: coa.Amount(types.NewAccountID(accounts.Nieodplatna), e.ID).Credit,
+			IncomeOther: coa.Amount(types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne,
+				accounts.Pozostale), e.ID).Credit,
+			CostPaid: coa.Amount(types.NewAccountID(accounts.Odplatna), e.ID).Debit,
+			CostFree: coa.Amount(types.NewAccountID(accounts.Nieodplatna), e.ID).Debit,
+			CostTaxed: coa.Amount(types.NewAccountID(accounts.PiK, accounts.Koszty,
+				accounts.Podatkowe), e.ID).Debit,
+			CostNotTaxed: coa.Amount(types.NewAccountID(accounts.PiK, accounts.Koszty,
+				accounts.Niepodatkowe), e.ID).Debit,
+		}
+		section.Records = append(section.Records, r)
+		section.Summary = section.Summary.AddRecord(r)
+	}
+
+	report := &ProjectReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Projects:       make([]ProjectSection, 0, len(sections)),
+	}
+	for _, section := range sections {
+		report.Projects = append(report.Projects, *section)
+	}
+	sort.Slice(report.Projects, func(i, j int) bool {
+		p1 := report.Projects[i].Project
+		p2 := report.Projects[j].Project
+		if p1 == "" || p2 == "" {
+			return p2 == ""
+		}
+		return p1 < p2
+	})
+
+	return types.ReportDocument{
+		Template: projectTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Projekty",
+			LockedRows: 4,
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed reminder.tmpl.xml
+	reminderTmpl     string
+	reminderTemplate = template.Must(template.New("reminder").Funcs(template.FuncMap{
+		"date": date,
+		"xml":  xmlText,
+	}).Parse(reminderTmpl))
+)
+
+// PaymentReminder requests the contractor to pay overdue receivables.
+type PaymentReminder struct {
+	Date           time.Time
+	CompanyName    string
+	CompanyAddress string
+	CompanyTaxID   string
+	Contractor     types.Contractor
+	InterestRate   types.Number
+	Commercial     bool
+	RateUnknown    bool
+	Records        []PaymentReminderRecord
+	Totals         []PaymentReminderTotal
+}
+
+// PaymentReminderRecord is the overdue document with interest. RateUnknown is set if statutory interest can't be
+// calculated because statutory rates applicable in the period are not known.
+type PaymentReminderRecord struct {
+	Document    types.Document
+	DueDate     time.Time
+	Days        int64
+	Amount      types.Denom
+	Interest    types.Denom
+	RateUnknown bool
+}
+
+// PaymentReminderTotal sums overdue amounts and interest in the currency.
+type PaymentReminderTotal struct {
+	Amount   types.Denom
+	Interest types.Denom
+	Total    types.Denom
+}
+
+// GeneratePaymentReminders generates payment reminders for contractors having overdue receivables as of the
+// reminder date. Interest is charged at the configured annual rate. If the rate is not set, statutory interest is
+// charged the same way as on interest notes and, if late interest is not enabled, interest for delay in commercial
+// transactions is charged to contractors having tax ID and interest for delay to other ones.
+func GeneratePaymentReminders(
+	reminders types.PaymentReminders,
+	interestType types.InterestType,
+	operations []types.Operation,
+	companyName, companyAddress, companyTaxID string,
+) []types.ExportDocument {
+	if reminders.Date.IsZero() {
+		return nil
+	}
+
+	docs := map[string]*PaymentReminder{}
+	for _, r := range OverDues(reminders.Date, operations) {
+		if r.Payable || r.Days <= 0 {
+			continue
+		}
+
+		statutoryType := reminderInterestType(interestType, r.Contractor)
+		doc, exists := docs[r.Contractor.Key()]
+		if !exists {
+			doc = &PaymentReminder{
+				Date:           reminders.Date,
+				CompanyName:    companyName,
+				CompanyAddress: companyAddress,
+				CompanyTaxID:   companyTaxID,
+				Contractor:     r.Contractor,
+				InterestRate:   reminders.InterestRate,
+				Commercial:     statutoryType == types.InterestTypeCommercial,
+			}
+			docs[r.Contractor.Key()] = doc
+		}
+
+		record := PaymentReminderRecord{
+			Document: r.Document,
+			DueDate:  r.DueDate,
+			Days:     r.Days,
+			Amount:   r.Amount,
+		}
+		if reminders.InterestRate.IsZero() {
+			var known bool
+			record.Interest, known = lateInterest(statutoryType, r.Amount, r.DueDate, reminders.Date)
+			record.RateUnknown = !known
+			doc.RateUnknown = doc.RateUnknown || !known
+		} else {
+			record.Interest = r.Amount.Interest(reminders.InterestRate, uint64(r.Days))
+		}
+		doc.Records = append(doc.Records, record)
+	}
+
+	keys := make([]string, 0, len(docs))
+	for key := range docs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Compare(docs[keys[i]].Contractor.Name, docs[keys[j]].Contractor.Name) < 0
+	})
+
+	exports := make([]types.ExportDocument, 0, len(keys))
+	for _, key := range keys {
+		doc := docs[key]
+		totals := map[types.CurrencySymbol]*PaymentReminderTotal{}
+		currencies := []types.CurrencySymbol{}
+		for _, r := range doc.Records {
+			total, exists := totals[r.Amount.Currency]
+			if !exists {
+				total = &PaymentReminderTotal{
+					Amount:   types.NewDenom(r.Amount.Currency),
+					Interest: types.NewDenom(r.Amount.Currency),
+					Total:    types.NewDenom(r.Amount.Currency),
+				}
+				totals[r.Amount.Currency] = total
+				currencies = append(currencies, r.Amount.Currency)
+			}
+			total.Amount = total.Amount.Add(r.Amount)
+			total.Interest = total.Interest.Add(r.Interest)
+			total.Total = total.Amount.Add(total.Interest)
+		}
+		for _, c := range currencies {
+			doc.Totals = append(doc.Totals, *totals[c])
+		}
+
+		exports = append(exports, types.ExportDocument{
+			FileName: fmt.Sprintf("Wezwanie-%s-%s.fodt", fileNamePart(key), reminders.Date.Format(time.DateOnly)),
+			Template: reminderTemplate,
+			Data:     doc,
+		})
+	}
+	return exports
+}
+
+func reminderInterestType(interestType types.InterestType, contractor types.Contractor) types.InterestType {
+	switch {
+	case interestType != types.InterestTypeNone:
+		return interestType
+	case contractor.TaxID != "":
+		return types.InterestTypeCommercial
+	default:
+		return types.InterestTypeDelay
+	}
+}
+
+func fileNamePart(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' {
+			return r
+		}
+		return '_'
+	}, s)
+}
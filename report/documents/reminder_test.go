@@ -0,0 +1,152 @@
+package documents_test
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+func TestPaymentReminders(t *testing.T) {
+	t.Parallel()
+
+	type record struct {
+		days        int64
+		amount      string
+		interest    string
+		rateUnknown bool
+	}
+
+	privateContractor := types.Contractor{Name: "Jan Kowalski"}
+	privateReceivable := receivable(day(2025, time.January, 10), "FS/2", dues(day(2025, time.January, 31), "1000.00"))
+	privateReceivable.Contractor = privateContractor
+
+	tests := []struct {
+		name         string
+		reminders    types.PaymentReminders
+		interestType types.InterestType
+		operations   []types.Operation
+		contractors  []string
+		commercial   []bool
+		records      [][]record
+	}{
+		{
+			name: "configured rate",
+			reminders: types.PaymentReminders{
+				Date:         day(2025, time.March, 2),
+				InterestRate: types.NewPercent(13, 25),
+			},
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00")),
+			},
+			contractors: []string{contractor.Name},
+			commercial:  []bool{true},
+			records: [][]record{
+				{{days: 30, amount: "1000.00", interest: "10.89"}},
+			},
+		},
+		{
+			name:      "statutory rate inferred from tax ID",
+			reminders: types.PaymentReminders{Date: day(2025, time.March, 2)},
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00")),
+				privateReceivable,
+			},
+			contractors: []string{privateContractor.Name, contractor.Name},
+			commercial:  []bool{false, true},
+			records: [][]record{
+				{{days: 30, amount: "1000.00", interest: "9.25"}},
+				{{days: 30, amount: "1000.00", interest: "12.95"}},
+			},
+		},
+		{
+			name:         "configured statutory interest type",
+			reminders:    types.PaymentReminders{Date: day(2025, time.March, 2)},
+			interestType: types.InterestTypeDelay,
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00")),
+			},
+			contractors: []string{contractor.Name},
+			commercial:  []bool{false},
+			records: [][]record{
+				{{days: 30, amount: "1000.00", interest: "9.25"}},
+			},
+		},
+		{
+			name:         "statutory rate after the end of the rate table",
+			reminders:    types.PaymentReminders{Date: day(2026, time.March, 1)},
+			interestType: types.InterestTypeDelay,
+			operations: []types.Operation{
+				receivable(day(2025, time.November, 10), "FS/1", dues(day(2025, time.December, 1), "1000.00")),
+			},
+			contractors: []string{contractor.Name},
+			commercial:  []bool{false},
+			records: [][]record{
+				{{days: 90, amount: "1000.00", interest: "0.00", rateUnknown: true}},
+			},
+		},
+		{
+			name: "configured rate after the end of the rate table",
+			reminders: types.PaymentReminders{
+				Date:         day(2026, time.March, 1),
+				InterestRate: types.NewPercent(10, 0),
+			},
+			operations: []types.Operation{
+				receivable(day(2025, time.November, 10), "FS/1", dues(day(2025, time.December, 1), "1000.00")),
+			},
+			contractors: []string{contractor.Name},
+			commercial:  []bool{true},
+			records: [][]record{
+				{{days: 90, amount: "1000.00", interest: "24.66"}},
+			},
+		},
+		{
+			name:      "not overdue",
+			reminders: types.PaymentReminders{Date: day(2025, time.January, 31)},
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00")),
+			},
+		},
+		{
+			name: "no date",
+			operations: []types.Operation{
+				receivable(day(2025, time.January, 10), "FS/1", dues(day(2025, time.January, 31), "1000.00")),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			exports := documents.GeneratePaymentReminders(tt.reminders, tt.interestType, tt.operations, "", "", "")
+			if len(exports) != len(tt.contractors) {
+				t.Fatalf("expected %d reminders, got %d", len(tt.contractors), len(exports))
+			}
+			for i, export := range exports {
+				if err := export.Template.Execute(io.Discard, export.Data); err != nil {
+					t.Fatal(err)
+				}
+				doc := export.Data.(*documents.PaymentReminder)
+				if doc.Contractor.Name != tt.contractors[i] || doc.Commercial != tt.commercial[i] {
+					t.Errorf("reminder %d: expected %s, commercial %t, got %s, %t", i, tt.contractors[i],
+						tt.commercial[i], doc.Contractor.Name, doc.Commercial)
+				}
+				if len(doc.Records) != len(tt.records[i]) {
+					t.Fatalf("reminder %d: expected %d records, got %d", i, len(tt.records[i]), len(doc.Records))
+				}
+				for j, r := range doc.Records {
+					e := tt.records[i][j]
+					if r.Days != e.days || r.RateUnknown != e.rateUnknown {
+						t.Errorf("record %d: expected days %d, unknown rate %t, got %d, %t", j, e.days,
+							e.rateUnknown, r.Days, r.RateUnknown)
+					}
+					assertDenom(t, "amount", e.amount, r.Amount)
+					assertDenom(t, "interest", e.interest, r.Interest)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package documents
+
+import (
+	_ "embed"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed trialbalance.tmpl.xml
+	trialBalanceTmpl     string
+	trialBalanceTemplate = template.Must(template.New("trialBalance").Parse(trialBalanceTmpl))
+)
+
+// TrialBalanceReport is the trial balance of all the accounts.
+type TrialBalanceReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Month          string
+	Year           uint64
+	Records        []TrialBalanceRecord
+	Summary        TrialBalanceSummary
+	Difference     types.Denom
+	Balanced       bool
+}
+
+// TrialBalanceRecord is the turnover and balance of single account.
+type TrialBalanceRecord struct {
+	Account          types.AccountInfo
+	Level            uint64
+	OpeningDebit     types.Denom
+	OpeningCredit    types.Denom
+	MonthDebit       types.Denom
+	MonthCredit      types.Denom
+	CumulativeDebit  types.Denom
+	CumulativeCredit types.Denom
+	ClosingDebit     types.Denom
+	ClosingCredit    types.Denom
+}
+
+// Indent returns the number of spaces indenting name of the account according to its level in the tree.
+func (r TrialBalanceRecord) Indent() uint64 {
+	return 4 * r.Level
+}
+
+// NewTrialBalanceSummary creates new trial balance summary.
+func NewTrialBalanceSummary() TrialBalanceSummary {
+	return TrialBalanceSummary{
+		OpeningDebit:     types.BaseZero,
+		OpeningCredit:    types.BaseZero,
+		MonthDebit:       types.BaseZero,
+		MonthCredit:      types.BaseZero,
+		CumulativeDebit:  types.BaseZero,
+		CumulativeCredit: types.BaseZero,
+		ClosingDebit:     types.BaseZero,
+		ClosingCredit:    types.BaseZero,
+	}
+}
+
+// TrialBalanceSummary is the summary of the top-level on-balance accounts.
+type TrialBalanceSummary struct {
+	OpeningDebit     types.Denom
+	OpeningCredit    types.Denom
+	MonthDebit       types.Denom
+	MonthCredit      types.Denom
+	CumulativeDebit  types.Denom
+	CumulativeCredit types.Denom
+	ClosingDebit     types.Denom
+	ClosingCredit    types.Denom
+}
+
+// AddRecord adds record to the summary.
+func (tbs TrialBalanceSummary) AddRecord(r TrialBalanceRecord) TrialBalanceSummary {
+	tbs.OpeningDebit = tbs.OpeningDebit.Add(r.OpeningDebit)
+	tbs.OpeningCredit = tbs.OpeningCredit.Add(r.OpeningCredit)
+	tbs.MonthDebit = tbs.MonthDebit.Add(r.MonthDebit)
+	tbs.MonthCredit = tbs.MonthCredit.Add(r.MonthCredit)
+	tbs.CumulativeDebit = tbs.CumulativeDebit.Add(r.CumulativeDebit)
+	tbs.CumulativeCredit = tbs.CumulativeCredit.Add(r.CumulativeCredit)
+	tbs.ClosingDebit = tbs.ClosingDebit.Add(r.ClosingDebit)
+	tbs.ClosingCredit = tbs.ClosingCredit.Add(r.ClosingCredit)
+	return tbs
+}
+
+// GenerateTrialBalanceReport generates trial balance of all the accounts for the month.
+func GenerateTrialBalanceReport(
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+	month time.Time,
+) types.ReportDocument {
+	report := &TrialBalanceReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Month:          monthName(month.Month()),
+		Year:           uint64(month.Year()),
+		Summary:        NewTrialBalanceSummary(),
+	}
+
+	for _, accountID := range coa.AccountIDs() {
+		opening := types.AccountBalance{
+			Debit:  coa.OpeningDebit(accountID),
+			Credit: coa.OpeningCredit(accountID),
+		}
+		closing := types.AccountBalance{
+			Debit:  coa.DebitIncremental(accountID, month),
+			Credit: coa.CreditIncremental(accountID, month),
+		}
+		r := TrialBalanceRecord{
+			Account:          coa.AccountInfo(accountID),
+			Level:            uint64(len(accountID) - 1),
+			OpeningDebit:     opening.Debit,
+			OpeningCredit:    opening.Credit,
+			MonthDebit:       coa.DebitMonth(accountID, month),
+			MonthCredit:      coa.CreditMonth(accountID, month),
+			CumulativeDebit:  closing.Debit.Sub(opening.Debit),
+			CumulativeCredit: closing.Credit.Sub(opening.Credit),
+			ClosingDebit:     types.BaseZero,
+			ClosingCredit:    types.BaseZero,
+		}
+		if balance := closing.Debit.Sub(closing.Credit); balance.GT(types.BaseZero) {
+			r.ClosingDebit = balance
+		} else {
+			r.ClosingCredit = balance.Neg()
+		}
+
+		report.Records = append(report.Records, r)
+		if len(accountID) == 1 && !r.Account.OffBalance {
+			report.Summary = report.Summary.AddRecord(r)
+		}
+	}
+	report.Difference = report.Summary.CumulativeDebit.Sub(report.Summary.CumulativeCredit)
+	report.Balanced = report.Difference.EQ(types.BaseZero)
+
+	return types.ReportDocument{
+		Template: trialBalanceTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "ZOiS",
+			LockedRows: 5,
+		},
+	}
+}
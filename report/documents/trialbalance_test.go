@@ -0,0 +1,148 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+var (
+	bankAccount      = types.NewAccountID(1)
+	fundAccount      = types.NewAccountID(2)
+	costsAccount     = types.NewAccountID(3)
+	materialsAccount = types.NewAccountID(3, 1)
+	servicesAccount  = types.NewAccountID(3, 2)
+	offBalance       = types.NewAccountID(4)
+)
+
+func smallChart() *types.ChartOfAccounts {
+	coa := types.NewChartOfAccounts(firstQuarter,
+		types.NewAccount(1, types.NewAccountLabel("130", "Bank", ""), types.Assets, types.AllValid()),
+		types.NewAccount(2, types.NewAccountLabel("800", "Fundusz", ""), types.Liabilities, types.AllValid()),
+		types.NewAccount(3, types.NewAccountLabel("400", "Koszty", ""), types.Costs, types.AllValid(),
+			types.NewAccount(1, types.NewAccountLabel("01", "Materiały", ""), types.Costs, types.AllValid()),
+			types.NewAccount(2, types.NewAccountLabel("02", "Usługi", ""), types.Costs, types.AllValid()),
+		),
+		types.OffBalance(types.NewAccount(4, types.NewAccountLabel("990", "Pozabilansowe", ""), types.Costs,
+			types.AllValid())),
+	)
+	coa.OpenAccount(bankAccount, types.DebitBalance(pln("1000.00")))
+	coa.OpenAccount(fundAccount, types.CreditBalance(pln("1000.00")))
+	return coa
+}
+
+func book(coa *types.ChartOfAccounts, date time.Time, records ...types.EntryRecord) {
+	coa.AddEntry(&operations.Purchase{Date: date}, records...)
+}
+
+func TestTrialBalanceReport(t *testing.T) {
+	t.Parallel()
+
+	coa := smallChart()
+	book(coa, day(2025, time.January, 10),
+		types.NewEntryRecord(materialsAccount, types.DebitBalance(pln("100.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("100.00"))),
+	)
+	book(coa, day(2025, time.February, 5),
+		types.NewEntryRecord(servicesAccount, types.DebitBalance(pln("50.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("50.00"))),
+	)
+	book(coa, day(2025, time.February, 20),
+		types.NewEntryRecord(offBalance, types.DebitBalance(pln("70.00"))),
+	)
+	book(coa, day(2025, time.March, 3),
+		types.NewEntryRecord(servicesAccount, types.DebitBalance(pln("20.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("20.00"))),
+	)
+
+	type record struct {
+		opening    [2]string
+		month      [2]string
+		cumulative [2]string
+		closing    [2]string
+	}
+
+	expected := []struct {
+		accountID types.AccountID
+		level     uint64
+		record    record
+	}{
+		{accountID: bankAccount, record: record{
+			opening: [2]string{"1000.00", "0"}, month: [2]string{"0", "50.00"},
+			cumulative: [2]string{"0", "150.00"}, closing: [2]string{"850.00", "0"},
+		}},
+		{accountID: costsAccount, record: record{
+			opening: [2]string{"0", "0"}, month: [2]string{"50.00", "0"},
+			cumulative: [2]string{"150.00", "0"}, closing: [2]string{"150.00", "0"},
+		}},
+		{accountID: materialsAccount, level: 1, record: record{
+			opening: [2]string{"0", "0"}, month: [2]string{"0", "0"},
+			cumulative: [2]string{"100.00", "0"}, closing: [2]string{"100.00", "0"},
+		}},
+		{accountID: servicesAccount, level: 1, record: record{
+			opening: [2]string{"0", "0"}, month: [2]string{"50.00", "0"},
+			cumulative: [2]string{"50.00", "0"}, closing: [2]string{"50.00", "0"},
+		}},
+		{accountID: fundAccount, record: record{
+			opening: [2]string{"0", "1000.00"}, month: [2]string{"0", "0"},
+			cumulative: [2]string{"0", "0"}, closing: [2]string{"0", "1000.00"},
+		}},
+		{accountID: offBalance, record: record{
+			opening: [2]string{"0", "0"}, month: [2]string{"70.00", "0"},
+			cumulative: [2]string{"70.00", "0"}, closing: [2]string{"70.00", "0"},
+		}},
+	}
+
+	report := documents.GenerateTrialBalanceReport(coa, "", "", day(2025, time.February, 1)).
+		Data.(*documents.TrialBalanceReport)
+	if len(report.Records) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(report.Records))
+	}
+	for i, e := range expected {
+		r := report.Records[i]
+		if r.Account.ID.String() != e.accountID.String() || r.Level != e.level {
+			t.Errorf("record %d: expected account %s level %d, got %s level %d", i, e.accountID, e.level,
+				r.Account.ID, r.Level)
+		}
+		name := r.Account.Name
+		assertDenom(t, name+" opening debit", e.record.opening[0], r.OpeningDebit)
+		assertDenom(t, name+" opening credit", e.record.opening[1], r.OpeningCredit)
+		assertDenom(t, name+" month debit", e.record.month[0], r.MonthDebit)
+		assertDenom(t, name+" month credit", e.record.month[1], r.MonthCredit)
+		assertDenom(t, name+" cumulative debit", e.record.cumulative[0], r.CumulativeDebit)
+		assertDenom(t, name+" cumulative credit", e.record.cumulative[1], r.CumulativeCredit)
+		assertDenom(t, name+" closing debit", e.record.closing[0], r.ClosingDebit)
+		assertDenom(t, name+" closing credit", e.record.closing[1], r.ClosingCredit)
+	}
+
+	// Off-balance account is not summed.
+	assertDenom(t, "summary opening debit", "1000.00", report.Summary.OpeningDebit)
+	assertDenom(t, "summary opening credit", "1000.00", report.Summary.OpeningCredit)
+	assertDenom(t, "summary month debit", "50.00", report.Summary.MonthDebit)
+	assertDenom(t, "summary month credit", "50.00", report.Summary.MonthCredit)
+	assertDenom(t, "summary closing debit", "1000.00", report.Summary.ClosingDebit)
+	assertDenom(t, "summary closing credit", "1000.00", report.Summary.ClosingCredit)
+	if !report.Balanced || !report.Difference.Amount.IsZero() {
+		t.Errorf("expected balanced trial balance, difference %s", report.Difference.Amount)
+	}
+}
+
+func TestTrialBalanceReportUnbalanced(t *testing.T) {
+	t.Parallel()
+
+	coa := smallChart()
+	book(coa, day(2025, time.January, 10),
+		types.NewEntryRecord(materialsAccount, types.DebitBalance(pln("100.00"))),
+		types.NewEntryRecord(bankAccount, types.CreditBalance(pln("70.00"))),
+	)
+
+	report := documents.GenerateTrialBalanceReport(coa, "", "", day(2025, time.January, 1)).
+		Data.(*documents.TrialBalanceReport)
+	if report.Balanced {
+		t.Error("expected unbalanced trial balance")
+	}
+	assertDenom(t, "difference", "30.00", report.Difference)
+}
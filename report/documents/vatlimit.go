@@ -0,0 +1,148 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed vatlimit.tmpl.xml
+	vatLimitTmpl     string
+	vatLimitTemplate = template.Must(template.New("vatLimit").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(vatLimitTmpl))
+)
+
+// VATLimitReport monitors the sales limit of the subjective VAT exemption.
+type VATLimitReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Year           uint64
+	Limit          types.Denom
+	Sales          types.Denom
+	Percentage     types.Number
+	Months         []VATLimitMonth
+	Thresholds     []VATLimitThreshold
+	Exceeded       bool
+	Projected      bool
+	ProjectedDate  time.Time
+}
+
+// VATLimitMonth is the monthly progress of sales against the VAT limit.
+type VATLimitMonth struct {
+	Month      string
+	Sales      types.Denom
+	Cumulative types.Denom
+	Percentage types.Number
+	Status     string
+}
+
+// VATLimitThreshold is the warning threshold of the VAT limit.
+type VATLimitThreshold struct {
+	Percentage types.Number
+	Amount     types.Denom
+	Reached    bool
+	Date       time.Time
+	Document   types.Document
+}
+
+// GenerateVATLimitReport generates the report monitoring the sales limit of the subjective VAT exemption.
+func GenerateVATLimitReport(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	companyName, companyAddress string,
+	limit types.VATLimit,
+) types.ReportDocument {
+	year := period.Start.Year()
+	report := &VATLimitReport{
+		CompanyName:    companyName,
+		CompanyAddress: companyAddress,
+		Year:           uint64(year),
+		Limit:          limit.Effective(year),
+		Sales:          types.BaseZero,
+	}
+
+	for _, t := range types.VATLimitThresholds {
+		report.Thresholds = append(report.Thresholds, VATLimitThreshold{
+			Percentage: t,
+			Amount:     report.Limit.Percent(t),
+		})
+	}
+
+	for _, month := range period.Months() {
+		m := VATLimitMonth{
+			Month: monthName(month.Month()),
+			Sales: types.BaseZero,
+		}
+		for _, e := range coa.EntriesMonth(types.NewAccountID(accounts.VAT), month) {
+			m.Sales = m.Sales.Add(e.Amount.Credit)
+			report.Sales = report.Sales.Add(e.Amount.Credit)
+			for i, t := range report.Thresholds {
+				if !t.Reached && report.Sales.GTE(t.Amount) {
+					report.Thresholds[i].Reached = true
+					report.Thresholds[i].Date = e.GetDate()
+					report.Thresholds[i].Document = e.GetDocument()
+				}
+			}
+		}
+		m.Cumulative = report.Sales
+		m.Percentage = m.Cumulative.PercentOf(report.Limit)
+		m.Status = vatLimitStatus(m.Percentage)
+		report.Months = append(report.Months, m)
+	}
+
+	report.Percentage = report.Sales.PercentOf(report.Limit)
+	report.Exceeded = report.Sales.GT(report.Limit)
+	if !report.Exceeded {
+		report.ProjectedDate, report.Projected = projectVATLimitDate(period, limit, report.Sales, report.Limit)
+	}
+
+	return types.ReportDocument{
+		Template: vatLimitTemplate,
+		Data:     report,
+		Config: types.SheetConfig{
+			Name:       "Limit VAT",
+			LockedRows: 4,
+		},
+	}
+}
+
+func vatLimitStatus(percentage types.Number) string {
+	status := ""
+	for _, t := range types.VATLimitThresholds {
+		if percentage.GTE(t) {
+			status = fmt.Sprintf("przekroczono %s%% limitu", t)
+		}
+	}
+	return status
+}
+
+// projectVATLimitDate estimates the date on which the limit is exceeded if sales continue at the average daily
+// pace observed so far.
+func projectVATLimitDate(
+	period types.Period,
+	limit types.VATLimit,
+	sales, effectiveLimit types.Denom,
+) (time.Time, bool) {
+	if !sales.GT(types.BaseZero) {
+		return time.Time{}, false
+	}
+
+	start := types.MaxDate(period.Start, limit.ActivityStart)
+	days := math.Ceil(period.End.Sub(start).Hours() / 24)
+	pace := sales.Amount.ToFloat64() / days
+	remaining := effectiveLimit.Sub(sales).Amount.ToFloat64()
+
+	projectedDate := period.End.AddDate(0, 0, int(math.Floor(remaining/pace))+1)
+	yearEnd := time.Date(period.Start.Year()+1, time.January, 1, 0, 0, 0, 0, period.Start.Location())
+	if !projectedDate.Before(yearEnd) {
+		return time.Time{}, false
+	}
+	return projectedDate, true
+}
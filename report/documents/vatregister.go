@@ -0,0 +1,391 @@
+package documents
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+var (
+	//go:embed vatregister.tmpl.xml
+	vatRegisterTmpl     string
+	vatRegisterTemplate = template.Must(template.New("vatRegister").Funcs(template.FuncMap{
+		"date": date,
+	}).Parse(vatRegisterTmpl))
+
+	//go:embed vatsettlement.tmpl.xml
+	vatSettlementTmpl     string
+	vatSettlementTemplate = template.Must(template.New("vatSettlement").Parse(vatSettlementTmpl))
+
+	//go:embed jpkv7m.tmpl.xml
+	jpkV7MTmpl     string
+	jpkV7MTemplate = template.Must(template.New("jpkV7M").Funcs(template.FuncMap{
+		"date":  date,
+		"units": units,
+		"xml":   xmlText,
+	}).Parse(jpkV7MTmpl))
+)
+
+// VATRegisterReport is the register of sales or purchases subject to VAT.
+type VATRegisterReport struct {
+	Title          string
+	SheetName      string
+	CompanyName    string
+	CompanyAddress string
+	Purchases      bool
+	Records        []VATRegisterRecord
+	Summary        VATRegisterSummary
+}
+
+// VATRegisterRecord is the document in the VAT register.
+type VATRegisterRecord struct {
+	Index      uint64
+	Date       time.Time
+	Document   types.Document
+	Contractor types.Contractor
+	Amounts    []types.VATAmount
+}
+
+// Total returns the total amounts of the record.
+func (r VATRegisterRecord) Total() VATRegisterSummary {
+	return NewVATRegisterSummary().AddRecord(r)
+}
+
+// NewVATRegisterSummary creates new VAT register summary.
+func NewVATRegisterSummary() VATRegisterSummary {
+	return VATRegisterSummary{
+		Net:   types.BaseZero,
+		VAT:   types.BaseZero,
+		Gross: types.BaseZero,
+	}
+}
+
+// VATRegisterSummary is the summary of the VAT register.
+type VATRegisterSummary struct {
+	Net   types.Denom
+	VAT   types.Denom
+	Gross types.Denom
+}
+
+// AddRecord adds record to the summary.
+func (vrs VATRegisterSummary) AddRecord(r VATRegisterRecord) VATRegisterSummary {
+	for _, a := range r.Amounts {
+		vrs.Net = vrs.Net.Add(a.Net)
+		vrs.VAT = vrs.VAT.Add(a.VAT)
+		vrs.Gross = vrs.Gross.Add(a.Gross())
+	}
+	return vrs
+}
+
+// VATSettlementReport is the monthly settlement of VAT.
+type VATSettlementReport struct {
+	CompanyName    string
+	CompanyAddress string
+	Months         []VATSettlementMonth
+}
+
+// VATSettlementMonth is the VAT settlement of the month, in full currency units as declared.
+type VATSettlementMonth struct {
+	Year       uint64
+	Month      uint64
+	MonthName  string
+	Sales      map[types.VATRate]types.VATAmount
+	SalesNet   types.Denom
+	OutputVAT  types.Denom
+	Carried    types.Denom
+	InputNet   types.Denom
+	InputVAT   types.Denom
+	InputTotal types.Denom
+	ToPay      types.Denom
+	Excess     types.Denom
+}
+
+// SalesAt returns sales at the VAT rate.
+func (m VATSettlementMonth) SalesAt(rate string) types.VATAmount {
+	sales, exists := m.Sales[types.VATRate(rate)]
+	if !exists {
+		return types.VATAmount{Rate: types.VATRate(rate), Net: types.BaseZero, VAT: types.BaseZero}
+	}
+	return sales
+}
+
+// JPKV7M is the JPK_V7M file combining VAT registers with the VAT-7 declaration of the month.
+type JPKV7M struct {
+	CreatedAt     time.Time
+	CompanyName   string
+	CompanyTaxID  string
+	TaxOfficeCode string
+	Settlement    VATSettlementMonth
+	Sales         []VATRegisterRecord
+	SalesVAT      types.Denom
+	Purchases     []VATRegisterRecord
+	PurchasesVAT  types.Denom
+}
+
+type outputVATSource interface {
+	types.EntryDataSource
+
+	OutputVAT(rates types.CurrencyRates) []types.VATAmount
+}
+
+type inputVATSource interface {
+	types.EntryDataSource
+
+	InputVAT(rates types.CurrencyRates) []types.VATAmount
+}
+
+// GenerateVATSalesRegister generates the register of sales subject to VAT.
+func GenerateVATSalesRegister(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	return generateVATRegister("REJESTR SPRZEDAŻY VAT", "Rejestr sprzedaży VAT", companyName, companyAddress, false,
+		vatSales(rates, vatOperations(operations, period.Contains)))
+}
+
+// GenerateVATPurchaseRegister generates the register of purchases subject to VAT.
+func GenerateVATPurchaseRegister(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	return generateVATRegister("REJESTR ZAKUPÓW VAT", "Rejestr zakupów VAT", companyName, companyAddress, true,
+		vatPurchases(rates, vatOperations(operations, period.Contains), false))
+}
+
+// GenerateVATSettlementReport generates the monthly settlement of VAT.
+func GenerateVATSettlementReport(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	companyName, companyAddress string,
+) types.ReportDocument {
+	return types.ReportDocument{
+		Template: vatSettlementTemplate,
+		Data: &VATSettlementReport{
+			CompanyName:    companyName,
+			CompanyAddress: companyAddress,
+			Months:         vatSettlement(period, operations, rates),
+		},
+		Config: types.SheetConfig{
+			Name:       "Rozliczenie VAT",
+			LockedRows: 4,
+		},
+	}
+}
+
+// GenerateJPKV7M generates JPK_V7M files for the months in which VAT was settled.
+func GenerateJPKV7M(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+	createdAt time.Time,
+	companyName, companyTaxID, taxOfficeCode string,
+) []types.ExportDocument {
+	docs := []types.ExportDocument{}
+	settlement := vatSettlement(period, operations, rates)
+	for i, month := range period.Months() {
+		ops := vatOperations(operations, inMonth(month))
+		sales := vatSales(rates, ops)
+		purchases := vatPurchases(rates, ops, true)
+		if len(sales) == 0 && len(purchases) == 0 {
+			continue
+		}
+		if taxOfficeCode == "" {
+			panic("tax office code is not set")
+		}
+
+		jpk := &JPKV7M{
+			CreatedAt:     createdAt,
+			CompanyName:   companyName,
+			CompanyTaxID:  companyTaxID,
+			TaxOfficeCode: taxOfficeCode,
+			Settlement:    settlement[i],
+			Sales:         sales,
+			SalesVAT:      NewVATRegisterSummary().AddRecords(sales).VAT,
+			Purchases:     purchases,
+			PurchasesVAT:  NewVATRegisterSummary().AddRecords(purchases).VAT,
+		}
+		docs = append(docs, types.ExportDocument{
+			FileName: fmt.Sprintf("JPK_V7M-%d-%02d.xml", month.Year(), month.Month()),
+			Template: jpkV7MTemplate,
+			Data:     jpk,
+		})
+	}
+	return docs
+}
+
+// AddRecords adds records to the summary.
+func (vrs VATRegisterSummary) AddRecords(records []VATRegisterRecord) VATRegisterSummary {
+	for _, r := range records {
+		vrs = vrs.AddRecord(r)
+	}
+	return vrs
+}
+
+func generateVATRegister(
+	title, sheetName, companyName, companyAddress string,
+	purchases bool,
+	records []VATRegisterRecord,
+) types.ReportDocument {
+	return types.ReportDocument{
+		Template: vatRegisterTemplate,
+		Data: &VATRegisterReport{
+			Title:          title,
+			SheetName:      sheetName,
+			CompanyName:    companyName,
+			CompanyAddress: companyAddress,
+			Purchases:      purchases,
+			Records:        records,
+			Summary:        NewVATRegisterSummary().AddRecords(records),
+		},
+		Config: types.SheetConfig{
+			Name:       sheetName,
+			LockedRows: 4,
+		},
+	}
+}
+
+// vatOperations returns operations with VAT amounts dated on the days accepted by the filter, sorted by date.
+func vatOperations(operations []types.Operation, filter func(date time.Time) bool) []types.EntryDataSource {
+	sources := []types.EntryDataSource{}
+	for _, op := range operations {
+		switch source := op.(type) {
+		case outputVATSource:
+			if filter(source.GetDate()) {
+				sources = append(sources, source)
+			}
+		case inputVATSource:
+			if filter(source.GetDate()) {
+				sources = append(sources, source)
+			}
+		}
+	}
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].GetDate().Before(sources[j].GetDate())
+	})
+	return sources
+}
+
+func inMonth(month time.Time) func(date time.Time) bool {
+	return func(date time.Time) bool {
+		return date.Year() == month.Year() && date.Month() == month.Month()
+	}
+}
+
+func vatSales(rates types.CurrencyRates, sources []types.EntryDataSource) []VATRegisterRecord {
+	records := []VATRegisterRecord{}
+	for _, s := range sources {
+		source, ok := s.(outputVATSource)
+		if !ok {
+			continue
+		}
+		records = appendVATRecord(records, source, source.OutputVAT(rates))
+	}
+	return records
+}
+
+func vatPurchases(
+	rates types.CurrencyRates,
+	sources []types.EntryDataSource,
+	deductibleOnly bool,
+) []VATRegisterRecord {
+	records := []VATRegisterRecord{}
+	for _, s := range sources {
+		source, ok := s.(inputVATSource)
+		if !ok {
+			continue
+		}
+		amounts := []types.VATAmount{}
+		for _, a := range source.InputVAT(rates) {
+			if a.Deductible || !deductibleOnly {
+				amounts = append(amounts, a)
+			}
+		}
+		records = appendVATRecord(records, source, amounts)
+	}
+	return records
+}
+
+func appendVATRecord(
+	records []VATRegisterRecord,
+	source types.EntryDataSource,
+	amounts []types.VATAmount,
+) []VATRegisterRecord {
+	if len(amounts) == 0 {
+		return records
+	}
+	return append(records, VATRegisterRecord{
+		Index:      uint64(len(records) + 1),
+		Date:       source.GetDate(),
+		Document:   source.GetDocument(),
+		Contractor: source.GetContractor(),
+		Amounts:    amounts,
+	})
+}
+
+func vatSettlement(
+	period types.Period,
+	operations []types.Operation,
+	rates types.CurrencyRates,
+) []VATSettlementMonth {
+	months := []VATSettlementMonth{}
+	carried := types.BaseZero
+	for _, month := range period.Months() {
+		ops := vatOperations(operations, inMonth(month))
+		m := VATSettlementMonth{
+			Year:      uint64(month.Year()),
+			Month:     uint64(month.Month()),
+			MonthName: monthName(month.Month()),
+			Sales:     map[types.VATRate]types.VATAmount{},
+			SalesNet:  types.BaseZero,
+			OutputVAT: types.BaseZero,
+			Carried:   carried,
+			InputNet:  types.BaseZero,
+			InputVAT:  types.BaseZero,
+			ToPay:     types.BaseZero,
+			Excess:    types.BaseZero,
+		}
+
+		for _, r := range vatSales(rates, ops) {
+			for _, a := range r.Amounts {
+				sales, exists := m.Sales[a.Rate]
+				if !exists {
+					sales = types.VATAmount{Rate: a.Rate, Net: types.BaseZero, VAT: types.BaseZero}
+				}
+				sales.Net = sales.Net.Add(a.Net)
+				sales.VAT = sales.VAT.Add(a.VAT)
+				m.Sales[a.Rate] = sales
+			}
+		}
+		for rate, sales := range m.Sales {
+			sales.Net = sales.Net.RoundUnits()
+			sales.VAT = sales.VAT.RoundUnits()
+			m.Sales[rate] = sales
+			m.SalesNet = m.SalesNet.Add(sales.Net)
+			m.OutputVAT = m.OutputVAT.Add(sales.VAT)
+		}
+
+		purchases := NewVATRegisterSummary().AddRecords(vatPurchases(rates, ops, true))
+		m.InputNet = purchases.Net.RoundUnits()
+		m.InputVAT = purchases.VAT.RoundUnits()
+		m.InputTotal = m.Carried.Add(m.InputVAT)
+
+		if m.OutputVAT.GT(m.InputTotal) {
+			m.ToPay = m.OutputVAT.Sub(m.InputTotal)
+		} else {
+			m.Excess = m.InputTotal.Sub(m.OutputVAT)
+		}
+		carried = m.Excess
+
+		months = append(months, m)
+	}
+	return months
+}
@@ -0,0 +1,197 @@
+package documents_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+func pln(amount string) types.Denom {
+	d, err := types.ParseDenom(amount, types.PLN)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func assertDenom(t *testing.T, name, expected string, actual types.Denom) {
+	t.Helper()
+
+	if actual.NEQ(pln(expected)) {
+		t.Errorf("%s: expected %s, got %s", name, expected, actual.Amount)
+	}
+}
+
+func sell(date time.Time, id types.DocumentID, lines ...types.InvoiceLine) *operations.Sell {
+	return &operations.Sell{
+		Date:     date,
+		Document: types.Document{ID: id, Date: date},
+		Lines:    lines,
+	}
+}
+
+func sellLine(rate types.VATRate, gross string) types.InvoiceLine {
+	return types.InvoiceLine{
+		Amount:   pln(gross),
+		SellType: types.SellTypeRecorded,
+		VATRate:  rate,
+	}
+}
+
+func purchase(date time.Time, id types.DocumentID, lines ...types.PurchaseVATLine) *operations.Purchase {
+	amount := types.BaseZero
+	for _, l := range lines {
+		amount = amount.Add(l.Gross)
+	}
+	return &operations.Purchase{
+		Date:     date,
+		Document: types.Document{ID: id, Date: date},
+		Amount:   amount,
+		VATLines: lines,
+	}
+}
+
+func purchaseLine(rate types.VATRate, gross string, deductible bool) types.PurchaseVATLine {
+	return types.PurchaseVATLine{
+		Rate:       rate,
+		Gross:      pln(gross),
+		Deductible: deductible,
+	}
+}
+
+var firstQuarter = types.Period{
+	Start: day(2025, time.January, 1),
+	End:   day(2025, time.March, 31),
+}
+
+func TestVATSettlement(t *testing.T) {
+	t.Parallel()
+
+	type month struct {
+		outputVAT string
+		carried   string
+		inputVAT  string
+		toPay     string
+		excess    string
+	}
+
+	tests := []struct {
+		name       string
+		operations []types.Operation
+		months     []month
+	}{
+		{
+			name: "no operations",
+			months: []month{
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+			},
+		},
+		{
+			name: "sums rounded to full units per rate",
+			operations: []types.Operation{
+				sell(day(2025, time.January, 10), "FS/1", sellLine(types.VATRate23, "61.81")),
+				sell(day(2025, time.January, 20), "FS/2", sellLine(types.VATRate23, "61.81"),
+					sellLine(types.VATRate8, "1.08")),
+				purchase(day(2025, time.January, 15), "FZ/1", purchaseLine(types.VATRate23, "12.92", true)),
+			},
+			months: []month{
+				// VAT at 23%: 11.56 + 11.56 = 23.12, at 8%: 0.08, input VAT: 2.42.
+				{outputVAT: "23", carried: "0", inputVAT: "2", toPay: "21", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+			},
+		},
+		{
+			name: "excess carried forward",
+			operations: []types.Operation{
+				purchase(day(2025, time.January, 5), "FZ/1", purchaseLine(types.VATRate23, "246.00", true)),
+				sell(day(2025, time.February, 5), "FS/1", sellLine(types.VATRate23, "123.00")),
+				sell(day(2025, time.March, 5), "FS/2", sellLine(types.VATRate23, "246.00")),
+			},
+			months: []month{
+				{outputVAT: "0", carried: "0", inputVAT: "46", toPay: "0", excess: "46"},
+				{outputVAT: "23", carried: "46", inputVAT: "0", toPay: "0", excess: "23"},
+				{outputVAT: "46", carried: "23", inputVAT: "0", toPay: "23", excess: "0"},
+			},
+		},
+		{
+			name: "non-deductible VAT, zero rates and operations outside the period are skipped",
+			operations: []types.Operation{
+				purchase(day(2025, time.January, 5), "FZ/1", purchaseLine(types.VATRate23, "246.00", false)),
+				sell(day(2025, time.January, 5), "FS/1", sellLine(types.VATRate0, "100.00"),
+					sellLine(types.VATRateNotRelevant, "50.00")),
+				sell(day(2024, time.December, 31), "FS/2", sellLine(types.VATRate23, "123.00")),
+				sell(day(2025, time.April, 1), "FS/3", sellLine(types.VATRate23, "123.00")),
+			},
+			months: []month{
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+				{outputVAT: "0", carried: "0", inputVAT: "0", toPay: "0", excess: "0"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			report := documents.GenerateVATSettlementReport(firstQuarter, tt.operations, nil, "", "").
+				Data.(*documents.VATSettlementReport)
+			if len(report.Months) != len(tt.months) {
+				t.Fatalf("expected %d months, got %d", len(tt.months), len(report.Months))
+			}
+			for i, m := range report.Months {
+				e := tt.months[i]
+				assertDenom(t, m.MonthName+" output VAT", e.outputVAT, m.OutputVAT)
+				assertDenom(t, m.MonthName+" carried", e.carried, m.Carried)
+				assertDenom(t, m.MonthName+" input VAT", e.inputVAT, m.InputVAT)
+				assertDenom(t, m.MonthName+" to pay", e.toPay, m.ToPay)
+				assertDenom(t, m.MonthName+" excess", e.excess, m.Excess)
+			}
+		})
+	}
+}
+
+func TestVATRegisters(t *testing.T) {
+	t.Parallel()
+
+	ops := []types.Operation{
+		sell(day(2025, time.February, 10), "FS/2", sellLine(types.VATRate23, "123.00")),
+		sell(day(2025, time.January, 10), "FS/1", sellLine(types.VATRate23, "123.00"),
+			sellLine(types.VATRateExempt, "10.00"), sellLine(types.VATRateNotRelevant, "5.00")),
+		sell(day(2025, time.January, 11), "FS/3", sellLine(types.VATRateNotRelevant, "5.00")),
+		purchase(day(2025, time.January, 15), "FZ/1", purchaseLine(types.VATRate23, "246.00", false),
+			purchaseLine(types.VATRate8, "108.00", true)),
+		sell(day(2025, time.April, 1), "FS/4", sellLine(types.VATRate23, "123.00")),
+	}
+
+	sales := documents.GenerateVATSalesRegister(firstQuarter, ops, nil, "", "").Data.(*documents.VATRegisterReport)
+	if len(sales.Records) != 2 {
+		t.Fatalf("expected 2 sales, got %d", len(sales.Records))
+	}
+	if sales.Records[0].Document.ID != "FS/1" || sales.Records[1].Document.ID != "FS/2" {
+		t.Errorf("sales not sorted by date: %s, %s", sales.Records[0].Document.ID, sales.Records[1].Document.ID)
+	}
+	if sales.Records[0].Index != 1 || sales.Records[1].Index != 2 {
+		t.Errorf("invalid indexes: %d, %d", sales.Records[0].Index, sales.Records[1].Index)
+	}
+	assertDenom(t, "sales net", "210.00", sales.Summary.Net)
+	assertDenom(t, "sales VAT", "46.00", sales.Summary.VAT)
+
+	purchases := documents.GenerateVATPurchaseRegister(firstQuarter, ops, nil, "", "").
+		Data.(*documents.VATRegisterReport)
+	if len(purchases.Records) != 1 {
+		t.Fatalf("expected 1 purchase, got %d", len(purchases.Records))
+	}
+	assertDenom(t, "purchases net", "300.00", purchases.Summary.Net)
+	assertDenom(t, "purchases VAT", "54.00", purchases.Summary.VAT)
+}
@@ -3,6 +3,7 @@ package report
 import (
 	"bytes"
 	_ "embed"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -18,67 +19,224 @@ import (
 	"github.com/outofforest/uepik/v2/types/operations"
 )
 
-var coaAccounts = []*types.Account{
-	types.NewAccount(
-		accounts.PiK, types.Liabilities, types.AllValid(),
+// DefaultChartOfAccounts returns the default chart of accounts.
+func DefaultChartOfAccounts() []*types.Account {
+	return []*types.Account{
 		types.NewAccount(
-			accounts.Przychody, types.Incomes, types.AllValid(),
+			accounts.SrodkiTrwale, types.NewAccountLabel("010", "Środki trwałe", "Wartość początkowa"),
+			types.Assets, types.AllValid(),
+		),
+		types.NewAccount(
+			accounts.Umorzenie, types.NewAccountLabel("070", "Umorzenie środków trwałych", ""),
+			types.Liabilities, types.AllValid(),
+		),
+		types.NewAccount(
+			accounts.Bank, types.NewAccountLabel("130", "Rachunek bankowy", ""),
+			types.Assets, types.AllValid(),
+		),
+		types.NewAccount(
+			accounts.Kontrahenci, types.NewAccountLabel("200", "Rozrachunki z kontrahentami", ""),
+			types.Assets, types.AllValid(),
+		),
+		types.NewAccount(
+			accounts.Fundusz, types.NewAccountLabel("800", "Fundusz statutowy", ""),
+			types.Liabilities, types.AllValid(),
+		),
+		types.NewAccount(
+			accounts.PiK, types.NewAccountLabel("700", "Przychody i koszty", "Wynik działalności statutowej"),
+			types.Liabilities, types.AllValid(),
+			types.NewAccount(
+				accounts.Przychody, types.NewAccountLabel("01", "Przychody", ""),
+				types.Incomes, types.AllValid(),
+				types.NewAccount(
+					accounts.Finansowe, types.NewAccountLabel("01", "Przychody finansowe", ""),
+					types.Incomes, types.AllValid(),
+					types.NewAccount(
+						accounts.DodatnieRozniceKursowe, types.NewAccountLabel("01", "Dodatnie różnice kursowe", ""),
+						types.Incomes, types.ValidSources(&operations.CurrencyDiffSource{}),
+					),
+					types.NewAccount(
+						accounts.Odsetki, types.NewAccountLabel("02", "Odsetki za opóźnienie", "Noty odsetkowe"),
+						types.Incomes, types.ValidSources(&operations.InterestNoteSource{}),
+					),
+				),
+				types.NewAccount(
+					accounts.Operacyjne, types.NewAccountLabel("02", "Przychody operacyjne", ""),
+					types.Incomes, types.AllValid(),
+					types.NewAccount(
+						accounts.Nieodplatna, types.NewAccountLabel("01", "Przychody nieodpłatne", "Darowizny"),
+						types.Incomes, types.ValidSources(&operations.Donation{}),
+					),
+					types.NewAccount(
+						accounts.Dotacje, types.NewAccountLabel("02", "Dotacje", "Na działalność statutową"),
+						types.Incomes, types.ValidSources(&operations.Grant{}),
+					),
+					types.NewAccount(
+						accounts.Odplatna, types.NewAccountLabel("03", "Przychody odpłatne", ""),
+						types.Incomes, types.ValidSources(
+							&operations.Sell{},
+							&operations.UnrecordedSellSource{},
+						),
+					),
+					types.NewAccount(
+						accounts.Pozostale, types.NewAccountLabel("04", "Pozostałe przychody operacyjne", ""),
+						types.Incomes, types.ValidSources(
+							&operations.Sell{},
+							&operations.UnrecordedSellSource{},
+						),
+					),
+				),
+			),
+			types.NewAccount(
+				accounts.Koszty, types.NewAccountLabel("02", "Koszty", ""),
+				types.Costs, types.AllValid(),
+				types.NewAccount(
+					accounts.Podatkowe, types.NewAccountLabel("01", "Koszty uzyskania przychodu", ""),
+					types.Costs, types.AllValid(),
+					types.NewAccount(
+						accounts.Finansowe, types.NewAccountLabel("01", "Koszty finansowe", ""),
+						types.Costs, types.AllValid(),
+						types.NewAccount(
+							accounts.UjemneRozniceKursowe, types.NewAccountLabel("01", "Ujemne różnice kursowe", ""),
+							types.Costs, types.ValidSources(&operations.CurrencyDiffSource{}),
+						),
+					),
+					types.NewAccount(
+						accounts.Operacyjne, types.NewAccountLabel("02", "Koszty operacyjne", ""),
+						types.Costs, types.ValidSources(&operations.Purchase{}),
+					),
+					types.NewAccount(
+						accounts.Amortyzacja, types.NewAccountLabel("03", "Amortyzacja", ""),
+						types.Costs, types.ValidSources(&operations.DepreciationSource{}),
+					),
+					types.NewAccount(
+						accounts.Wynagrodzenia, types.NewAccountLabel("04", "Wynagrodzenia", ""),
+						types.Costs, types.ValidSources(&operations.Contract{}),
+					),
+				),
+				types.NewAccount(
+					accounts.Niepodatkowe, types.NewAccountLabel("02", "Koszty niestanowiące KUP", ""),
+					types.Costs, types.AllValid(),
+					types.NewAccount(
+						accounts.Operacyjne, types.NewAccountLabel("02", "Koszty operacyjne", ""),
+						types.Costs, types.ValidSources(&operations.Purchase{}),
+					),
+					types.NewAccount(
+						accounts.Amortyzacja, types.NewAccountLabel("03", "Amortyzacja", ""),
+						types.Costs, types.ValidSources(&operations.DepreciationSource{}),
+					),
+					types.NewAccount(
+						accounts.Wynagrodzenia, types.NewAccountLabel("04", "Wynagrodzenia", ""),
+						types.Costs, types.ValidSources(&operations.Contract{}),
+					),
+				),
+			),
+		),
+		types.OffBalance(types.NewAccount(
+			accounts.VAT, types.NewAccountLabel("222", "Sprzedaż do limitu zwolnienia z VAT",
+				"Art. 113 ustawy o VAT"),
+			types.Incomes, types.ValidSources(&types.VAT{}),
+		)),
+		types.OffBalance(types.NewAccount(
+			accounts.NiewydatkowanyDochod, types.NewAccountLabel("820", "Niewydatkowany dochód", "Na cele statutowe"),
+			types.Liabilities, types.ValidSources(
+				&operations.CurrencyDiffSource{},
+				&operations.InterestNoteSource{},
+				&operations.Donation{},
+				&operations.Grant{},
+				&operations.Purchase{},
+				&operations.FixedAsset{},
+				&operations.Sell{},
+				&operations.Contract{},
+			),
+		)),
+		types.OffBalance(types.NewAccount(
+			accounts.RozniceKursowe, types.NewAccountLabel("750", "Różnice kursowe", ""),
+			types.Liabilities, types.AllValid(),
+			types.NewAccount(
+				accounts.Nieodplatna, types.NewAccountLabel("01", "Różnice kursowe nieodpłatne", ""),
+				types.Liabilities, types.ValidSources(&types.CurrencyDiff{}),
+			),
 			types.NewAccount(
-				accounts.Finansowe, types.Incomes, types.AllValid(),
-				types.NewAccount(accounts.DodatnieRozniceKursowe, types.Incomes,
-					types.ValidSources(&operations.CurrencyDiffSource{})),
+				accounts.Odplatna, types.NewAccountLabel("02", "Różnice kursowe odpłatne", ""),
+				types.Liabilities, types.ValidSources(&types.CurrencyDiff{}),
 			),
 			types.NewAccount(
-				accounts.Operacyjne, types.Incomes, types.AllValid(),
-				types.NewAccount(accounts.Nieodplatna, types.Incomes, types.ValidSources(&operations.Donation{})),
-				types.NewAccount(accounts.Odplatna, types.Incomes, types.ValidSources(
-					&operations.Sell{},
-					&operations.UnrecordedSellSource{},
-				)),
+				accounts.Pozostale, types.NewAccountLabel("03", "Pozostałe różnice kursowe", ""),
+				types.Liabilities, types.ValidSources(&types.CurrencyDiff{}),
+			),
+		)),
+		types.NewAccount(
+			accounts.RozliczenieVAT, types.NewAccountLabel("221", "Rozliczenie VAT", ""),
+			types.Liabilities, types.AllValid(),
+			types.NewAccount(
+				accounts.Nalezny, types.NewAccountLabel("01", "VAT należny", ""),
+				types.Liabilities, types.ValidSources(&operations.Sell{}),
+			),
+			types.NewAccount(
+				accounts.Naliczony, types.NewAccountLabel("02", "VAT naliczony", ""),
+				types.Liabilities, types.ValidSources(&operations.Purchase{}),
 			),
 		),
 		types.NewAccount(
-			accounts.Koszty, types.Costs, types.AllValid(),
+			accounts.Rozrachunki, types.NewAccountLabel("230", "Rozrachunki z tytułu umów", ""),
+			types.Liabilities, types.AllValid(),
 			types.NewAccount(
-				accounts.Podatkowe, types.Costs, types.AllValid(),
-				types.NewAccount(
-					accounts.Finansowe, types.Costs, types.AllValid(),
-					types.NewAccount(accounts.UjemneRozniceKursowe, types.Costs,
-						types.ValidSources(&operations.CurrencyDiffSource{})),
+				accounts.Wynagrodzenia, types.NewAccountLabel("01", "Rozrachunki z wykonawcami", ""),
+				types.Liabilities, types.ValidSources(
+					&operations.Contract{},
+					&types.Settlement{},
+				),
+			),
+			types.NewAccount(
+				accounts.UrzadSkarbowy, types.NewAccountLabel("02", "Rozrachunki z urzędem skarbowym", ""),
+				types.Liabilities, types.ValidSources(
+					&operations.Contract{},
+					&types.Settlement{},
 				),
-				types.NewAccount(accounts.Operacyjne, types.Costs, types.ValidSources(&operations.Purchase{})),
 			),
 			types.NewAccount(
-				accounts.Niepodatkowe, types.Costs, types.AllValid(),
-				types.NewAccount(accounts.Operacyjne, types.Costs, types.ValidSources(&operations.Purchase{})),
+				accounts.ZUS, types.NewAccountLabel("03", "Rozrachunki z ZUS", ""),
+				types.Liabilities, types.ValidSources(
+					&operations.Contract{},
+					&types.Settlement{},
+				),
 			),
 		),
-	),
-	types.NewAccount(accounts.VAT, types.Incomes, types.ValidSources(&types.VAT{})),
-	types.NewAccount(
-		accounts.NiewydatkowanyDochod, types.Liabilities, types.ValidSources(
-			&operations.CurrencyDiffSource{},
-			&operations.Donation{},
-			&operations.Purchase{},
-			&operations.Sell{},
-		),
-	),
-	types.NewAccount(
-		accounts.RozniceKursowe, types.Liabilities, types.AllValid(),
-		types.NewAccount(accounts.Nieodplatna, types.Liabilities, types.ValidSources(&types.CurrencyDiff{})),
-		types.NewAccount(accounts.Odplatna, types.Liabilities, types.ValidSources(&types.CurrencyDiff{})),
-	),
-	types.NewAccount(accounts.SprzedazNieewidencjonowana, types.Incomes, types.ValidSources(&operations.Sell{})),
-	types.NewAccount(accounts.Nieodplatna, types.Liabilities, types.ValidSources(
-		&operations.CurrencyDiffSource{},
-		&operations.Donation{},
-		&operations.Purchase{},
-	)),
-	types.NewAccount(accounts.Odplatna, types.Liabilities, types.ValidSources(
-		&operations.CurrencyDiffSource{},
-		&operations.Sell{},
-		&operations.Purchase{},
-	)),
+		types.OffBalance(types.NewAccount(
+			accounts.SprzedazNieewidencjonowana, types.NewAccountLabel("705", "Sprzedaż nieewidencjonowana", ""),
+			types.Incomes, types.AllValid(),
+			types.NewAccount(
+				accounts.Odplatna, types.NewAccountLabel("01", "Sprzedaż nieewidencjonowana odpłatna", ""),
+				types.Incomes, types.ValidSources(&operations.Sell{}),
+			),
+			types.NewAccount(
+				accounts.Pozostale, types.NewAccountLabel("02", "Sprzedaż nieewidencjonowana pozostała", ""),
+				types.Incomes, types.ValidSources(&operations.Sell{}),
+			),
+		)),
+		types.OffBalance(types.NewAccount(
+			accounts.Nieodplatna, types.NewAccountLabel("500", "Działalność nieodpłatna", "Statutowa nieodpłatna"),
+			types.Liabilities, types.ValidSources(
+				&operations.CurrencyDiffSource{},
+				&operations.Donation{},
+				&operations.Grant{},
+				&operations.Purchase{},
+				&operations.FixedAsset{},
+				&operations.Contract{},
+			),
+		)),
+		types.OffBalance(types.NewAccount(
+			accounts.Odplatna, types.NewAccountLabel("501", "Działalność odpłatna", "Statutowa odpłatna"),
+			types.Liabilities, types.ValidSources(
+				&operations.CurrencyDiffSource{},
+				&operations.Sell{},
+				&operations.Purchase{},
+				&operations.FixedAsset{},
+				&operations.Contract{},
+			),
+		)),
+	}
 }
 
 //go:embed report.tmpl.xml
@@ -103,6 +261,10 @@ func Save(
 	f := lo.Must(os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600))
 	defer f.Close()
 	lo.Must0(tmplParsed.Execute(f, report))
+
+	for _, export := range report.Exports {
+		lo.Must0(os.WriteFile(filepath.Join("reports", export.FileName), []byte(export.Content), 0o600))
+	}
 }
 
 func newReport(
@@ -115,8 +277,56 @@ func newReport(
 		year.Period.End = viewDate
 	}
 
-	coa := types.NewChartOfAccounts(year.Period, coaAccounts...)
+	chart := year.Chart
+	if chart == nil {
+		chart = DefaultChartOfAccounts()
+	}
+	coa := types.NewChartOfAccounts(year.Period, chart...)
+	for _, e := range year.ChartExtensions {
+		coa.AddAccount(e.Parent, e.Account)
+	}
+	if year.Chart != nil {
+		verifyChart(coa)
+	}
+	coa.AddBookingRules(year.BookingRules...)
 	coa.OpenAccount(types.NewAccountID(accounts.NiewydatkowanyDochod), types.CreditBalance(year.Init.UnspentProfit))
+	openingAssets := openingFixedAssets(year, years, currencyRates)
+	if year.Balanced {
+		coa.EnableBalancedMode(types.BalancedMode{
+			Bank:         types.NewAccountID(accounts.Bank),
+			Settlements:  types.NewAccountID(accounts.Kontrahenci),
+			Fund:         types.NewAccountID(accounts.Fundusz),
+			FixedAssets:  types.NewAccountID(accounts.SrodkiTrwale),
+			Depreciation: types.NewAccountID(accounts.Umorzenie),
+		})
+
+		cash := types.BaseZero
+		for _, ci := range year.Init.Currencies {
+			cash = cash.Add(ci.BaseSum)
+		}
+		coa.OpenAccount(types.NewAccountID(accounts.Bank), types.DebitBalance(cash))
+
+		fund := cash
+		if len(openingAssets) > 0 {
+			initialValue := types.BaseZero
+			accumulated := types.BaseZero
+			for _, fa := range openingAssets {
+				initialValue = initialValue.Add(fa.Asset.InitialValue)
+				for _, d := range fa.Asset.DepreciationPlan() {
+					if d.GetDate().Before(year.Period.Start) {
+						accumulated = accumulated.Add(d.Amount)
+					}
+				}
+			}
+			coa.OpenAccount(types.NewAccountID(accounts.SrodkiTrwale), types.DebitBalance(initialValue))
+			coa.OpenAccount(types.NewAccountID(accounts.Umorzenie), types.CreditBalance(accumulated))
+			fund = fund.Add(initialValue).Sub(accumulated)
+		}
+		coa.OpenAccount(types.NewAccountID(accounts.Fundusz), types.CreditBalance(fund))
+	}
+	for _, fa := range openingAssets {
+		year.Operations = append(year.Operations, fa)
+	}
 
 	company := types.Contractor{
 		Name:    year.CompanyName,
@@ -132,15 +342,36 @@ func newReport(
 			Contractor: company,
 		},
 	)
+	if year.LateInterest.Type != types.InterestTypeNone && year.LateInterest.Book {
+		year.Operations = append(year.Operations, &operations.InterestNotes{
+			Type:       year.LateInterest.Type,
+			Operations: year.Operations,
+		})
+	}
 
 	bankRecords, opBankRecords := year.BankReports(currencyRates, years)
 
 	opDocs := year.BookRecords(coa, currencyRates, opBankRecords)
 
+	donors := documents.Donors(year.Period, year.Operations, currencyRates)
+
+	// Contracts are reported in the year of the payment, which might be the next one.
+	allOperations := []types.Operation{}
+	for _, y := range years {
+		allOperations = append(allOperations, y.Operations...)
+	}
+
 	docs := []types.ReportDocument{
 		documents.GenerateBookReport(year.Period, coa, year.CompanyName),
 		documents.GenerateFlowReport(year.Period, coa, year.CompanyName),
 		documents.GenerateVATReport(year.Period, coa, year.CompanyName, year.CompanyAddress),
+		documents.GenerateVATLimitReport(year.Period, coa, year.CompanyName, year.CompanyAddress, year.VATLimit),
+		documents.GenerateVATSalesRegister(year.Period, year.Operations, currencyRates, year.CompanyName,
+			year.CompanyAddress),
+		documents.GenerateVATPurchaseRegister(year.Period, year.Operations, currencyRates, year.CompanyName,
+			year.CompanyAddress),
+		documents.GenerateVATSettlementReport(year.Period, year.Operations, currencyRates, year.CompanyName,
+			year.CompanyAddress),
 		documents.GenerateCategoryReport(year.Period, coa, year.CompanyName, year.CompanyAddress,
 			"ZESTAWIENIE DZIAŁALNOŚCI NIEODPŁATNEJ",
 			"Nieodpłatna",
@@ -149,7 +380,20 @@ func newReport(
 			"ZESTAWIENIE DZIAŁALNOŚCI ODPŁATNEJ",
 			"Odpłatna",
 			types.NewAccountID(accounts.Odplatna)),
-		documents.GenerateCIT8Report(coa),
+		documents.GenerateProjectReport(coa, year.CompanyName, year.CompanyAddress),
+		documents.GenerateBudgetReport(year.Period, coa, year.CompanyName, year.CompanyAddress, year.Budget),
+		documents.GenerateChartOfAccountsReport(coa, year.CompanyName, year.CompanyAddress),
+		documents.GenerateTrialBalanceReport(coa, year.CompanyName, year.CompanyAddress, year.Period.End),
+		documents.GenerateCIT8Report(coa, donors, year.EffectiveDonorDisclosureLimit()),
+		documents.GenerateFixedAssetsReport(year.Period, year.CompanyName, year.CompanyAddress, year.Operations,
+			currencyRates),
+		documents.GenerateDepreciationPlanReport(year.Period, year.CompanyName, year.CompanyAddress,
+			year.Operations, currencyRates),
+		documents.GenerateEquipmentReport(year.Period, year.CompanyName, year.CompanyAddress, year.Operations),
+		documents.GeneratePIT4RReport(year.Period, allOperations, year.CompanyName, year.CompanyAddress,
+			year.CompanyTaxID, year.TaxOfficeCode),
+		documents.GeneratePIT11Report(year.Period, allOperations, year.CompanyName, year.CompanyAddress,
+			year.CompanyTaxID, year.TaxOfficeCode),
 	}
 	currencies := lo.Keys(bankRecords)
 	sort.Slice(currencies, func(i, j int) bool {
@@ -163,13 +407,51 @@ func newReport(
 		docs = append(docs, documents.GenerateBankReport(year.Period, year.CompanyName, year.CompanyAddress,
 			types.Currencies.Currency(c), ci, bankRecords[c]))
 	}
-	docs = append(docs, documents.GenerateOverDueReport(year.Period, year.Operations))
+	docs = append(docs, documents.GenerateBalanceCheckReport(year.Period, coa, year.CompanyName,
+		year.CompanyAddress)...)
+	docs = append(docs, documents.GenerateLedgerReport(year.Period, coa, year.CompanyName, year.CompanyAddress,
+		year.Ledger)...)
+	docs = append(docs, documents.GenerateLateInterestReport(year.Period, year.LateInterest.Type, year.Operations,
+		year.CompanyName, year.CompanyAddress)...)
+	docs = append(docs, documents.GenerateBankStatementReport(year.Period, year.BankTransactions, year.Operations,
+		year.CompanyName, year.CompanyAddress)...)
+	docs = append(docs, documents.GenerateOverDueReport(year.Period, year.Operations, currencyRates, year.CompanyName,
+		year.CompanyAddress))
+
+	docs = append(docs, documents.GenerateDonorsReport(year.Period, donors, year.EffectiveDonorDisclosureLimit(),
+		year.CompanyName, year.CompanyAddress))
+
+	ledgers := types.NewContractorLedgers(year.Operations, opBankRecords, currencyRates)
+	docs = append(docs, documents.GenerateOpenItemsReport(ledgers, year.CompanyName, year.CompanyAddress,
+		year.Period.End, "Rozrachunki"))
+	for _, date := range year.OpenItemsDates {
+		docs = append(docs, documents.GenerateOpenItemsReport(ledgers, year.CompanyName, year.CompanyAddress,
+			date, "Rozrachunki "+date.Format(time.DateOnly)))
+	}
+	docs = append(docs, documents.GenerateContractorStatements(year.Period, ledgers, year.CompanyName,
+		year.CompanyAddress)...)
 	docs = append(docs, opDocs...)
 
+	exports := documents.GeneratePayrollDeclarations(year.Period, allOperations, year.CompanyName, year.CompanyTaxID,
+		year.TaxOfficeCode)
+	exports = append(exports, documents.GenerateInvoices(year.Period, year.Operations, currencyRates, company,
+		year.BankAccounts, year.VATExemptionBasis)...)
+	exports = append(exports, documents.GenerateKSeFInvoices(year.Period, year.Operations, currencyRates, viewDate,
+		company, year.BankAccounts, year.VATExemptionBasis)...)
+	exports = append(exports, documents.GenerateCIT8Declaration(year.Period, coa, donors,
+		year.EffectiveDonorDisclosureLimit(), viewDate, year.CompanyName, year.CompanyTaxID, year.TaxOfficeCode)...)
+	exports = append(exports, documents.GenerateDonationConfirmations(year.Period, donors, viewDate, year.CompanyName,
+		year.CompanyAddress, year.CompanyTaxID, year.CompanyKRS, year.PublicBenefit)...)
+	exports = append(exports, documents.GeneratePaymentReminders(year.Reminders, year.LateInterest.Type,
+		year.Operations, year.CompanyName, year.CompanyAddress, year.CompanyTaxID)...)
+	exports = append(exports, documents.GenerateJPKV7M(year.Period, year.Operations, currencyRates, viewDate,
+		year.CompanyName, year.CompanyTaxID, year.TaxOfficeCode)...)
+
 	report := types.Report{
 		Currencies: lo.Values(types.Currencies),
 		Configs:    make([]string, 0, len(docs)),
 		Documents:  make([]string, 0, len(docs)),
+		Exports:    make([]types.ExportFile, 0, len(exports)),
 	}
 
 	buf := &bytes.Buffer{}
@@ -183,5 +465,78 @@ func newReport(
 		report.Configs = append(report.Configs, buf.String())
 	}
 
+	for _, export := range exports {
+		buf.Reset()
+		lo.Must0(export.Template.Execute(buf, export.Data))
+		report.Exports = append(report.Exports, types.ExportFile{
+			FileName: export.FileName,
+			Content:  buf.String(),
+		})
+	}
+
 	return report
 }
+
+// verifyChart verifies that the custom chart of accounts contains all the accounts of the default one, because
+// operations and reports book and read them.
+func verifyChart(coa *types.ChartOfAccounts) {
+	defaultCOA := types.NewChartOfAccounts(types.Period{}, DefaultChartOfAccounts()...)
+	missing := []string{}
+	for _, accountID := range defaultCOA.AccountIDs() {
+		if !coa.HasAccount(accountID) {
+			info := defaultCOA.AccountInfo(accountID)
+			missing = append(missing, info.Number+" "+strings.Join(info.Path, " / "))
+		}
+	}
+	if len(missing) > 0 {
+		panic(fmt.Sprintf("chart of accounts misses accounts required by operations: %s",
+			strings.Join(missing, ", ")))
+	}
+}
+
+// openingFixedAssets returns operations depreciating fixed assets carried forward to the year from its opening
+// balance and from the previous years.
+func openingFixedAssets(
+	year *types.FiscalYear,
+	years []*types.FiscalYear,
+	rates types.CurrencyRates,
+) []*operations.FixedAsset {
+	assets := []*operations.FixedAsset{}
+	inventory := map[string]bool{}
+	add := func(asset types.OpeningFixedAsset) {
+		if !asset.Asset.Date.Before(year.Period.Start) {
+			panic(fmt.Sprintf("opening fixed asset %s is acquired in the fiscal year", asset.Asset.InventoryNumber))
+		}
+		if inventory[asset.Asset.InventoryNumber] {
+			return
+		}
+		inventory[asset.Asset.InventoryNumber] = true
+		assets = append(assets, operations.NewOpeningFixedAsset(asset))
+	}
+
+	for _, y := range years {
+		if !y.Period.Start.Before(year.Period.Start) {
+			continue
+		}
+		for _, asset := range y.Init.FixedAssets {
+			add(asset)
+		}
+		for _, op := range y.Operations {
+			if fa, ok := op.(*operations.FixedAsset); ok && !fa.Opening && fa.Date.Before(year.Period.Start) {
+				add(fa.OpeningFixedAsset(rates))
+			}
+		}
+	}
+	for _, asset := range year.Init.FixedAssets {
+		add(asset)
+	}
+
+	for _, op := range year.Operations {
+		if fa, ok := op.(*operations.FixedAsset); ok && inventory[fa.Asset.InventoryNumber] {
+			panic(fmt.Sprintf("fixed asset %s is already carried forward from the opening balance",
+				fa.Asset.InventoryNumber))
+		}
+	}
+
+	return assets
+}
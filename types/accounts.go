@@ -1,8 +1,11 @@
 package types
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -11,6 +14,7 @@ func NewChartOfAccounts(period Period, accounts ...*Account) *ChartOfAccounts {
 	ch := &ChartOfAccounts{
 		period:   period,
 		accounts: map[AccountIDPart]*Account{},
+		numbers:  map[string]AccountID{},
 	}
 
 	for _, account := range accounts {
@@ -19,6 +23,7 @@ func NewChartOfAccounts(period Period, accounts ...*Account) *ChartOfAccounts {
 		}
 		ch.accounts[account.idPart] = account
 	}
+	ch.registerNumbers()
 
 	return ch
 }
@@ -27,7 +32,147 @@ func NewChartOfAccounts(period Period, accounts ...*Account) *ChartOfAccounts {
 type ChartOfAccounts struct {
 	period   Period
 	accounts map[AccountIDPart]*Account
+	numbers  map[string]AccountID
+	rules    []BookingRule
 	entryID  EntryID
+
+	balancedMode *BalancedMode
+	violations   []BalanceViolation
+}
+
+// BalancedMode defines accounts used in the double-entry mode, in which debits and credits of each entry must be
+// equal.
+type BalancedMode struct {
+	Bank         AccountID
+	Settlements  AccountID
+	Fund         AccountID
+	FixedAssets  AccountID
+	Depreciation AccountID
+}
+
+// BalanceViolation is the entry in which debits and credits of on-balance accounts are not equal.
+type BalanceViolation struct {
+	Data   EntryDataSource
+	Debit  Denom
+	Credit Denom
+}
+
+// EnableBalancedMode turns on the double-entry mode.
+func (ch *ChartOfAccounts) EnableBalancedMode(mode BalancedMode) {
+	if ch.entryID > 0 {
+		panic("balanced mode must be enabled before booking")
+	}
+	for _, accountID := range []AccountID{mode.Bank, mode.Settlements, mode.Fund, mode.FixedAssets,
+		mode.Depreciation} {
+		if ch.isOffBalance(accountID) {
+			panic("balanced mode requires on-balance accounts")
+		}
+	}
+	ch.balancedMode = &mode
+}
+
+// BalancedMode returns accounts of the double-entry mode and if the mode is enabled.
+func (ch *ChartOfAccounts) BalancedMode() (BalancedMode, bool) {
+	if ch.balancedMode == nil {
+		return BalancedMode{}, false
+	}
+	return *ch.balancedMode, true
+}
+
+// Violations returns entries which are not balanced.
+func (ch *ChartOfAccounts) Violations() []BalanceViolation {
+	return ch.violations
+}
+
+// BookBankRecord books the bank record against the settlements account in the double-entry mode.
+func (ch *ChartOfAccounts) BookBankRecord(data EntryDataSource, br *BankRecord) {
+	if ch.balancedMode == nil {
+		return
+	}
+
+	amount := br.BaseAmount
+	if amount.GT(BaseZero) {
+		ch.AddEntry(data,
+			NewEntryRecord(ch.balancedMode.Bank, DebitBalance(amount)),
+			NewEntryRecord(ch.balancedMode.Settlements, CreditBalance(amount)),
+		)
+		return
+	}
+	ch.AddEntry(data,
+		NewEntryRecord(ch.balancedMode.Bank, CreditBalance(amount.Neg())),
+		NewEntryRecord(ch.balancedMode.Settlements, DebitBalance(amount.Neg())),
+	)
+}
+
+// AddAccount adds account to the chart under the parent one. Empty parent ID adds top-level account.
+func (ch *ChartOfAccounts) AddAccount(parentID AccountID, account *Account) {
+	if ch.entryID > 0 {
+		panic("accounts must be added before booking")
+	}
+
+	if len(parentID) == 0 {
+		if _, exists := ch.accounts[account.idPart]; exists {
+			panic("account already registered")
+		}
+		ch.accounts[account.idPart] = account
+	} else {
+		parent := ch.getAccount(parentID)
+		if !parent.openingBalance.IsZero() {
+			panic("cannot add child to opened account")
+		}
+		parent.addChild(account)
+	}
+	ch.registerNumbers()
+
+	for _, r := range ch.rules {
+		if len(ch.getAccount(r.To).children) > 0 {
+			panic(fmt.Sprintf("booking rule must point to a leaf account, account %s has children",
+				ch.getAccount(r.To).number))
+		}
+	}
+}
+
+// AddBookingRules adds rules redirecting records to other accounts. Rules are verified against types and valid
+// sources of the accounts.
+func (ch *ChartOfAccounts) AddBookingRules(rules ...BookingRule) {
+	for _, r := range rules {
+		from := ch.getAccount(r.From)
+		to := ch.getAccount(r.To)
+		if len(to.children) > 0 {
+			panic("booking rule must point to a leaf account")
+		}
+
+		sources := r.Sources
+		if sources == nil && from.validSourceTypes != nil {
+			sources = make([]reflect.Type, 0, len(from.validSourceTypes))
+			for sourceType := range from.validSourceTypes {
+				sources = append(sources, sourceType)
+			}
+		}
+
+		for i := range r.To {
+			account := ch.getAccount(r.To[:i+1])
+			if from.accountType.allowDebit && !account.accountType.allowDebit {
+				panic("booking rule: debit not allowed on target account")
+			}
+			if from.accountType.allowCredit && !account.accountType.allowCredit {
+				panic("booking rule: credit not allowed on target account")
+			}
+			if account.validSourceTypes == nil {
+				continue
+			}
+			if sources == nil {
+				panic("booking rule: target account does not accept all the sources")
+			}
+			for _, sourceType := range sources {
+				if _, exists := account.validSourceTypes[sourceType]; !exists {
+					panic("booking rule: target account does not accept the source")
+				}
+			}
+		}
+
+		ch.rules = append(ch.rules, r)
+	}
 }
 
 // OpenAccount sets initial balance on account.
@@ -66,6 +211,13 @@ func (ch *ChartOfAccounts) AddEntry(data EntryDataSource, records ...EntryRecord
 	entryID := ch.entryID
 	ch.entryID++
 
+	var sourceDimensions Dimensions
+	if ps, ok := data.(projectSource); ok && ps.GetProject() != "" {
+		sourceDimensions = Dimensions{DimensionProject: string(ps.GetProject())}
+	}
+
+	debit := BaseZero
+	credit := BaseZero
 	for _, r := range records {
 		if len(r.AccountID) == 0 {
 			panic("empty account ID")
@@ -73,21 +225,37 @@ func (ch *ChartOfAccounts) AddEntry(data EntryDataSource, records ...EntryRecord
 		if r.Amount.IsZero() {
 			continue
 		}
+		dimensions := sourceDimensions.Merge(r.Dimensions)
+		r = ch.applyRules(data, r, dimensions)
 
 		accounts := ch.accounts
 		var account *Account
+		offBalance := false
 		for _, idPart := range r.AccountID {
 			var exists bool
 			account, exists = accounts[idPart]
 			if !exists {
 				panic("account does not exist")
 			}
-			account.addEntry(entryID, data, r.Amount)
+			account.addEntry(entryID, data, r.Amount, dimensions)
+			offBalance = offBalance || account.offBalance
 			accounts = account.children
 		}
 		if len(account.children) > 0 {
 			panic("entry must be added to a leaf account")
 		}
+		if !offBalance {
+			debit = debit.Add(r.Amount.Debit)
+			credit = credit.Add(r.Amount.Credit)
+		}
+	}
+
+	if ch.balancedMode != nil && !debit.EQ(credit) {
+		ch.violations = append(ch.violations, BalanceViolation{
+			Data:   data,
+			Debit:  debit,
+			Credit: credit,
+		})
 	}
 }
 
@@ -97,6 +265,16 @@ func (ch *ChartOfAccounts) OpeningBalance(accountID AccountID) Denom {
 	return account.accountType.balanceFn(account.openingBalance)
 }
 
+// OpeningDebit returns debit side of the opening balance of the account.
+func (ch *ChartOfAccounts) OpeningDebit(accountID AccountID) Denom {
+	return ch.getAccount(accountID).openingBalance.Debit
+}
+
+// OpeningCredit returns credit side of the opening balance of the account.
+func (ch *ChartOfAccounts) OpeningCredit(accountID AccountID) Denom {
+	return ch.getAccount(accountID).openingBalance.Credit
+}
+
 // Debit returns debit balance on the account.
 func (ch *ChartOfAccounts) Debit(accountID AccountID) Denom {
 	account := ch.getAccount(accountID)
@@ -141,9 +319,13 @@ func (ch *ChartOfAccounts) CreditMonth(accountID AccountID, date time.Time) Deno
 	return balance.Credit
 }
 
-// BalanceMonth returns balance on the account in month.
-func (ch *ChartOfAccounts) BalanceMonth(accountID AccountID, date time.Time) Denom {
+// BalanceMonth returns balance on the account in month. If filters are passed, only the parts of entries matching
+// all of them are taken into account.
+func (ch *ChartOfAccounts) BalanceMonth(accountID AccountID, date time.Time, filters ...Dimension) Denom {
 	account := ch.getAccount(accountID)
+	if len(filters) > 0 {
+		return account.accountType.balanceFn(sumEntries(account.entriesMonth[newMonthKey(date)], filters))
+	}
 	balance, exists := account.balances[newMonthKey(date)]
 	if !exists {
 		return BaseZero
@@ -189,15 +371,21 @@ func (ch *ChartOfAccounts) CreditIncremental(accountID AccountID, date time.Time
 	return credit
 }
 
-// BalanceIncremental returns balance on the account in current month and all the previous ones.
-func (ch *ChartOfAccounts) BalanceIncremental(accountID AccountID, date time.Time) Denom {
+// BalanceIncremental returns balance on the account in current month and all the previous ones. Filters are applied
+// the same way as in Balance.
+func (ch *ChartOfAccounts) BalanceIncremental(accountID AccountID, date time.Time, filters ...Dimension) Denom {
 	account := ch.getAccount(accountID)
 	balance := account.openingBalance
+	if len(filters) > 0 {
+		balance = zeroAccountBalance
+	}
 
 	mKey := newMonthKey(date)
 	for _, month := range ch.period.Months() {
 		mKey2 := newMonthKey(month)
-		if sum2, exists := account.balances[mKey2]; exists {
+		if len(filters) > 0 {
+			balance = balance.Add(sumEntries(account.entriesMonth[mKey2], filters))
+		} else if sum2, exists := account.balances[mKey2]; exists {
 			balance = balance.Add(sum2)
 		}
 		if mKey2 == mKey {
@@ -208,9 +396,13 @@ func (ch *ChartOfAccounts) BalanceIncremental(accountID AccountID, date time.Tim
 	return account.accountType.balanceFn(balance)
 }
 
-// Balance returns balance on the account.
-func (ch *ChartOfAccounts) Balance(accountID AccountID) Denom {
+// Balance returns balance on the account. If filters are passed, only the parts of entries matching all of them are
+// taken into account. Opening balance has no dimensions, so it is skipped then.
+func (ch *ChartOfAccounts) Balance(accountID AccountID, filters ...Dimension) Denom {
 	account := ch.getAccount(accountID)
+	if len(filters) > 0 {
+		return account.accountType.balanceFn(sumEntries(account.entries, filters))
+	}
 	balance := account.openingBalance
 
 	for _, b := range account.balances {
@@ -220,6 +412,11 @@ func (ch *ChartOfAccounts) Balance(accountID AccountID) Denom {
 	return account.accountType.balanceFn(balance)
 }
 
+// BalanceOf returns balance of the amount according to the type of the account.
+func (ch *ChartOfAccounts) BalanceOf(accountID AccountID, amount AccountBalance) Denom {
+	return ch.getAccount(accountID).accountType.balanceFn(amount)
+}
+
 // Amount returns amount of the entry on the account.
 func (ch *ChartOfAccounts) Amount(accountID AccountID, entryID EntryID) AccountBalance {
 	entry, exists := ch.getAccount(accountID).entries[entryID]
@@ -229,18 +426,143 @@ func (ch *ChartOfAccounts) Amount(accountID AccountID, entryID EntryID) AccountB
 	return entry.Amount
 }
 
-// Entries returns entries on the account.
-func (ch *ChartOfAccounts) Entries(accountID AccountID) []*Entry {
-	return sortEntries(ch.getAccount(accountID).entries)
+// Entries returns entries on the account. If filters are passed, only entries having parts matching all of them are
+// returned, with amount limited to those parts.
+func (ch *ChartOfAccounts) Entries(accountID AccountID, filters ...Dimension) []*Entry {
+	return sortEntries(filterEntries(ch.getAccount(accountID).entries, filters))
 }
 
-// EntriesMonth returns entries on the account on month.
-func (ch *ChartOfAccounts) EntriesMonth(accountID AccountID, date time.Time) []*Entry {
+// EntriesMonth returns entries on the account on month. Filters are applied the same way as in Entries.
+func (ch *ChartOfAccounts) EntriesMonth(accountID AccountID, date time.Time, filters ...Dimension) []*Entry {
 	entries, exists := ch.getAccount(accountID).entriesMonth[newMonthKey(date)]
 	if !exists {
 		return nil
 	}
-	return sortEntries(entries)
+	return sortEntries(filterEntries(entries, filters))
+}
+
+// AccountInfo returns description of the account.
+func (ch *ChartOfAccounts) AccountInfo(accountID AccountID) AccountInfo {
+	account := ch.getAccount(accountID)
+	path := make([]string, 0, len(accountID))
+	for i := range accountID {
+		path = append(path, ch.getAccount(accountID[:i+1]).label.Name)
+	}
+	return AccountInfo{
+		ID:          accountID,
+		Number:      account.number,
+		Name:        account.label.Name,
+		Description: account.label.Description,
+		Path:        path,
+		Type:        account.typ,
+		OffBalance:  ch.isOffBalance(accountID),
+	}
+}
+
+// HasAccount returns true if the account exists in the chart.
+func (ch *ChartOfAccounts) HasAccount(accountID AccountID) bool {
+	accounts := ch.accounts
+	for _, idPart := range accountID {
+		account, exists := accounts[idPart]
+		if !exists {
+			return false
+		}
+		accounts = account.children
+	}
+	return len(accountID) > 0
+}
+
+// AccountIDByNumber returns ID of the account with the printable number.
+func (ch *ChartOfAccounts) AccountIDByNumber(number string) AccountID {
+	accountID, exists := ch.numbers[number]
+	if !exists {
+		panic("account does not exist")
+	}
+	return accountID
+}
+
+// AccountIDByPath returns ID of the account identified by the names of the accounts on the path from the root.
+func (ch *ChartOfAccounts) AccountIDByPath(names ...string) AccountID {
+	if len(names) == 0 {
+		panic("empty account path")
+	}
+
+	accountID := make(AccountID, 0, len(names))
+	accounts := ch.accounts
+	for _, name := range names {
+		var found *Account
+		for _, account := range accounts {
+			if account.label.Name == name {
+				found = account
+				break
+			}
+		}
+		if found == nil {
+			panic("account does not exist")
+		}
+		accountID = append(accountID, found.idPart)
+		accounts = found.children
+	}
+	return accountID
+}
+
+func (ch *ChartOfAccounts) registerNumbers() {
+	ch.numbers = map[string]AccountID{}
+	for _, accountID := range ch.AccountIDs() {
+		account := ch.getAccount(accountID)
+		account.number = account.label.Number
+		if len(accountID) > 1 {
+			account.number = ch.getAccount(accountID[:len(accountID)-1]).number + "-" + account.number
+		}
+		if _, exists := ch.numbers[account.number]; exists {
+			panic("account number already registered")
+		}
+		ch.numbers[account.number] = accountID
+	}
+}
+
+func (ch *ChartOfAccounts) applyRules(data EntryDataSource, record EntryRecord, dimensions Dimensions) EntryRecord {
+	for _, r := range ch.rules {
+		if r.matches(data, record.AccountID, dimensions) {
+			record.AccountID = r.To
+			return record
+		}
+	}
+	return record
+}
+
+// AccountIDs returns IDs of all the accounts in the chart ordered by number, parents before their children.
+func (ch *ChartOfAccounts) AccountIDs() []AccountID {
+	return appendAccountIDs(nil, nil, ch.accounts)
+}
+
+func appendAccountIDs(ids []AccountID, parentID AccountID, accounts map[AccountIDPart]*Account) []AccountID {
+	parts := make([]AccountIDPart, 0, len(accounts))
+	for idPart := range accounts {
+		parts = append(parts, idPart)
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		n1 := accounts[parts[i]].label.Number
+		n2 := accounts[parts[j]].label.Number
+		return n1 < n2 || (n1 == n2 && parts[i] < parts[j])
+	})
+
+	for _, idPart := range parts {
+		id := make(AccountID, 0, len(parentID)+1)
+		id = append(append(id, parentID...), idPart)
+		ids = append(ids, id)
+		ids = appendAccountIDs(ids, id, accounts[idPart].children)
+	}
+	return ids
+}
+
+func (ch *ChartOfAccounts) isOffBalance(accountID AccountID) bool {
+	for i := range accountID {
+		if ch.getAccount(accountID[:i+1]).offBalance {
+			return true
+		}
+	}
+	return false
 }
 
 func (ch *ChartOfAccounts) getAccount(accountID AccountID) *Account {
@@ -279,6 +601,15 @@ type AccountIDPart uint64
 // AccountID is an ID of account.
 type AccountID []AccountIDPart
 
+// String returns the hierarchical representation of the account ID.
+func (id AccountID) String() string {
+	parts := make([]string, 0, len(id))
+	for _, idPart := range id {
+		parts = append(parts, strconv.FormatUint(uint64(idPart), 10))
+	}
+	return strings.Join(parts, "-")
+}
+
 // NewAccountID builds account ID from parts.
 func NewAccountID(parts ...AccountIDPart) AccountID {
 	return parts
@@ -303,9 +634,44 @@ func NoneValid() []reflect.Type {
 	return []reflect.Type{}
 }
 
+// NewAccountLabel creates new account label.
+func NewAccountLabel(number, name, description string) AccountLabel {
+	if number == "" || strings.Contains(number, "-") {
+		panic("invalid account number")
+	}
+	if name == "" {
+		panic("empty account name")
+	}
+	return AccountLabel{
+		Number:      number,
+		Name:        name,
+		Description: description,
+	}
+}
+
+// AccountLabel defines human-readable identification of the account. Number is the segment of the printable number
+// of the account, appended to the number of the parent one.
+type AccountLabel struct {
+	Number      string
+	Name        string
+	Description string
+}
+
+// AccountInfo describes the account in the chart.
+type AccountInfo struct {
+	ID          AccountID
+	Number      string
+	Name        string
+	Description string
+	Path        []string
+	Type        AccountType
+	OffBalance  bool
+}
+
 // NewAccount creates new account.
 func NewAccount(
 	idPart AccountIDPart,
+	label AccountLabel,
 	accountType AccountType,
 	validSourceTypes []reflect.Type,
 	children ...*Account,
@@ -316,6 +682,8 @@ func NewAccount(
 	}
 	a := &Account{
 		idPart:         idPart,
+		label:          label,
+		typ:            accountType,
 		accountType:    accountTypeDef,
 		children:       map[AccountIDPart]*Account{},
 		entries:        map[EntryID]*Entry{},
@@ -331,20 +699,18 @@ func NewAccount(
 	}
 
 	for _, child := range children {
-		if _, exists := a.children[child.idPart]; exists {
-			panic("child account already registered")
-		}
-		if child.accountType.allowDebit && !accountTypeDef.allowDebit {
-			panic("debit not allowed on child account")
-		}
-		if child.accountType.allowCredit && !accountTypeDef.allowCredit {
-			panic("credit not allowed on child account")
-		}
-		a.children[child.idPart] = child
+		a.addChild(child)
 	}
 	return a
 }
 
+// OffBalance marks the account as the off-balance register. Its records are not taken into account when verifying
+// balance of the entries.
+func OffBalance(account *Account) *Account {
+	account.offBalance = true
+	return account
+}
+
 var zeroAccountBalance = AccountBalance{
 	Debit:  BaseZero,
 	Credit: BaseZero,
@@ -437,15 +803,32 @@ var accountTypes = map[AccountType]AccountTypeDefinition{
 type Account struct {
 	children         map[AccountIDPart]*Account
 	idPart           AccountIDPart
+	label            AccountLabel
+	number           string
+	typ              AccountType
 	accountType      AccountTypeDefinition
 	entries          map[EntryID]*Entry
 	entriesMonth     map[monthKey]map[EntryID]*Entry
 	openingBalance   AccountBalance
 	balances         map[monthKey]AccountBalance
 	validSourceTypes map[reflect.Type]struct{}
+	offBalance       bool
+}
+
+func (a *Account) addChild(child *Account) {
+	if _, exists := a.children[child.idPart]; exists {
+		panic("child account already registered")
+	}
+	if child.accountType.allowDebit && !a.accountType.allowDebit {
+		panic("debit not allowed on child account")
+	}
+	if child.accountType.allowCredit && !a.accountType.allowCredit {
+		panic("credit not allowed on child account")
+	}
+	a.children[child.idPart] = child
 }
 
-func (a *Account) addEntry(id EntryID, data EntryDataSource, amount AccountBalance) {
+func (a *Account) addEntry(id EntryID, data EntryDataSource, amount AccountBalance, dimensions Dimensions) {
 	verifyBalanceAndType(amount, a.accountType)
 	if a.validSourceTypes != nil {
 		if _, exists := a.validSourceTypes[reflect.TypeOf(data)]; !exists {
@@ -462,6 +845,7 @@ func (a *Account) addEntry(id EntryID, data EntryDataSource, amount AccountBalan
 		}
 	}
 	entry.Amount = entry.Amount.Add(amount)
+	entry.addPart(dimensions, amount)
 
 	a.entries[id] = entry
 	mKey := newMonthKey(data.GetDate())
@@ -473,7 +857,7 @@ func (a *Account) addEntry(id EntryID, data EntryDataSource, amount AccountBalan
 	if !exists {
 		sumMonth = zeroAccountBalance
 	}
-	a.balances[mKey] = sumMonth.Add(entry.Amount)
+	a.balances[mKey] = sumMonth.Add(amount)
 }
 
 // EntryID represents entry ID.
@@ -492,6 +876,39 @@ type Entry struct {
 	ID     EntryID
 	Data   EntryDataSource
 	Amount AccountBalance
+	Parts  []EntryPart
+}
+
+// EntryPart is the part of the entry amount sharing the same dimensions.
+type EntryPart struct {
+	Dimensions Dimensions
+	Amount     AccountBalance
+}
+
+func (e *Entry) addPart(dimensions Dimensions, amount AccountBalance) {
+	for i, p := range e.Parts {
+		if p.Dimensions.Equal(dimensions) {
+			e.Parts[i].Amount = p.Amount.Add(amount)
+			return
+		}
+	}
+	e.Parts = append(e.Parts, EntryPart{
+		Dimensions: dimensions,
+		Amount:     amount,
+	})
+}
+
+// Filter returns the amount of the parts matching all the filters.
+func (e *Entry) Filter(filters ...Dimension) (AccountBalance, bool) {
+	amount := zeroAccountBalance
+	var found bool
+	for _, p := range e.Parts {
+		if p.Dimensions.Match(filters...) {
+			amount = amount.Add(p.Amount)
+			found = true
+		}
+	}
+	return amount, found
 }
 
 // GetDate returns date of the entry.
@@ -524,8 +941,92 @@ func NewEntryRecord(accountID AccountID, amount AccountBalance) EntryRecord {
 
 // EntryRecord stores information about amount to add to the account.
 type EntryRecord struct {
-	AccountID AccountID
-	Amount    AccountBalance
+	AccountID  AccountID
+	Amount     AccountBalance
+	Dimensions Dimensions
+}
+
+// WithDimensions returns entry record tagged with dimensions.
+func (r EntryRecord) WithDimensions(dimensions ...Dimension) EntryRecord {
+	d := Dimensions{}
+	for _, dimension := range dimensions {
+		d[dimension.Key] = dimension.Value
+	}
+	r.Dimensions = r.Dimensions.Merge(d)
+	return r
+}
+
+// DimensionKey is the key of analytical dimension.
+type DimensionKey string
+
+// Predefined dimensions.
+const (
+	DimensionProject    DimensionKey = "project"
+	DimensionGrant      DimensionKey = "grant"
+	DimensionEvent      DimensionKey = "event"
+	DimensionCostCentre DimensionKey = "costCentre"
+)
+
+// Dimension is the analytical tag of the entry.
+type Dimension struct {
+	Key   DimensionKey
+	Value string
+}
+
+// NewDimension creates new dimension.
+func NewDimension(key DimensionKey, value string) Dimension {
+	if key == "" {
+		panic("empty dimension key")
+	}
+	return Dimension{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// Dimensions is the set of analytical tags.
+type Dimensions map[DimensionKey]string
+
+// Merge returns new dimensions extended by other ones. Conflicting values are not allowed.
+func (d Dimensions) Merge(other Dimensions) Dimensions {
+	result := make(Dimensions, len(d)+len(other))
+	for k, v := range d {
+		result[k] = v
+	}
+	for k, v := range other {
+		if v2, exists := result[k]; exists && v2 != v {
+			panic("conflicting dimension values")
+		}
+		result[k] = v
+	}
+	return result
+}
+
+// Equal checks if dimensions are equal.
+func (d Dimensions) Equal(other Dimensions) bool {
+	if len(d) != len(other) {
+		return false
+	}
+	for k, v := range d {
+		if v2, exists := other[k]; !exists || v2 != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Match checks if dimensions match all the filters.
+func (d Dimensions) Match(filters ...Dimension) bool {
+	for _, f := range filters {
+		if v, exists := d[f.Key]; !exists || v != f.Value {
+			return false
+		}
+	}
+	return true
+}
+
+type projectSource interface {
+	GetProject() Project
 }
 
 func verifyBalanceAndType(balance AccountBalance, accountType AccountTypeDefinition) {
@@ -537,6 +1038,32 @@ func verifyBalanceAndType(balance AccountBalance, accountType AccountTypeDefinit
 	}
 }
 
+func filterEntries(entries map[EntryID]*Entry, filters []Dimension) map[EntryID]*Entry {
+	if len(filters) == 0 {
+		return entries
+	}
+	results := map[EntryID]*Entry{}
+	for id, e := range entries {
+		amount, found := e.Filter(filters...)
+		if !found {
+			continue
+		}
+		e2 := *e
+		e2.Amount = amount
+		results[id] = &e2
+	}
+	return results
+}
+
+func sumEntries(entries map[EntryID]*Entry, filters []Dimension) AccountBalance {
+	balance := zeroAccountBalance
+	for _, e := range entries {
+		amount, _ := e.Filter(filters...)
+		balance = balance.Add(amount)
+	}
+	return balance
+}
+
 func sortEntries(entries map[EntryID]*Entry) []*Entry {
 	results := make([]*Entry, 0, len(entries))
 	for _, e := range entries {
@@ -0,0 +1,400 @@
+package types
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func testChart() *ChartOfAccounts {
+	return NewChartOfAccounts(Period{},
+		NewAccount(1, NewAccountLabel("100", "Koszty", ""), Costs, AllValid(),
+			NewAccount(2, NewAccountLabel("01", "Materiały", ""), Costs, AllValid()),
+			NewAccount(3, NewAccountLabel("02", "Usługi", ""), Costs, AllValid()),
+		),
+	)
+}
+
+func TestHasAccount(t *testing.T) {
+	t.Parallel()
+
+	coa := testChart()
+	tests := []struct {
+		accountID AccountID
+		expected  bool
+	}{
+		{accountID: NewAccountID(1), expected: true},
+		{accountID: NewAccountID(1, 2), expected: true},
+		{accountID: NewAccountID(2)},
+		{accountID: NewAccountID(1, 4)},
+		{accountID: NewAccountID(1, 2, 3)},
+		{accountID: NewAccountID()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.accountID.String(), func(t *testing.T) {
+			t.Parallel()
+
+			if coa.HasAccount(tt.accountID) != tt.expected {
+				t.Fatalf("expected %t", tt.expected)
+			}
+		})
+	}
+}
+
+func TestAddAccountUnderBookingRuleTarget(t *testing.T) {
+	t.Parallel()
+
+	coa := testChart()
+	coa.AddBookingRules(BookingRule{From: NewAccountID(1, 2), To: NewAccountID(1, 3)})
+
+	coa.AddAccount(NewAccountID(1, 2), NewAccount(4, NewAccountLabel("01", "Paliwo", ""), Costs, AllValid()))
+	assertPanics(t, func() {
+		coa.AddAccount(NewAccountID(1, 3), NewAccount(5, NewAccountLabel("01", "Transport", ""), Costs, AllValid()))
+	})
+}
+
+func TestDimensionsMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		d        Dimensions
+		other    Dimensions
+		expected Dimensions
+	}{
+		{
+			name:     "both empty",
+			expected: Dimensions{},
+		},
+		{
+			name:     "other empty",
+			d:        Dimensions{DimensionProject: "Rejs"},
+			expected: Dimensions{DimensionProject: "Rejs"},
+		},
+		{
+			name:     "first empty",
+			other:    Dimensions{DimensionGrant: "Dotacja"},
+			expected: Dimensions{DimensionGrant: "Dotacja"},
+		},
+		{
+			name:     "both set",
+			d:        Dimensions{DimensionProject: "Rejs", DimensionEvent: "Regaty"},
+			other:    Dimensions{DimensionProject: "Rejs", DimensionGrant: "Dotacja"},
+			expected: Dimensions{DimensionProject: "Rejs", DimensionEvent: "Regaty", DimensionGrant: "Dotacja"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := tt.d.Merge(tt.other)
+			if !result.Equal(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, result)
+			}
+
+			result[DimensionCostCentre] = "Biuro"
+			if _, exists := tt.d[DimensionCostCentre]; exists {
+				t.Error("merged dimensions share the map with the first ones")
+			}
+			if _, exists := tt.other[DimensionCostCentre]; exists {
+				t.Error("merged dimensions share the map with the other ones")
+			}
+		})
+	}
+}
+
+func TestDimensionsMergeConflict(t *testing.T) {
+	t.Parallel()
+
+	assertPanics(t, func() {
+		Dimensions{DimensionProject: "Rejs"}.Merge(Dimensions{DimensionProject: "Regaty"})
+	})
+}
+
+func TestAccountNumbers(t *testing.T) {
+	t.Parallel()
+
+	coa := testChart()
+	coa.AddAccount(NewAccountID(1, 2), NewAccount(4, NewAccountLabel("01", "Paliwo", ""), Costs, AllValid()))
+
+	tests := []struct {
+		number    string
+		accountID AccountID
+		path      []string
+	}{
+		{number: "100", accountID: NewAccountID(1), path: []string{"Koszty"}},
+		{number: "100-01", accountID: NewAccountID(1, 2), path: []string{"Koszty", "Materiały"}},
+		{number: "100-02", accountID: NewAccountID(1, 3), path: []string{"Koszty", "Usługi"}},
+		{number: "100-01-01", accountID: NewAccountID(1, 2, 4), path: []string{"Koszty", "Materiały", "Paliwo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			t.Parallel()
+
+			if accountID := coa.AccountIDByNumber(tt.number); accountID.String() != tt.accountID.String() {
+				t.Errorf("expected account %s, got %s", tt.accountID, accountID)
+			}
+			if accountID := coa.AccountIDByPath(tt.path...); accountID.String() != tt.accountID.String() {
+				t.Errorf("expected account %s by path, got %s", tt.accountID, accountID)
+			}
+			info := coa.AccountInfo(tt.accountID)
+			if info.Number != tt.number || strings.Join(info.Path, "/") != strings.Join(tt.path, "/") {
+				t.Errorf("expected number %s and path %v, got %s and %v", tt.number, tt.path, info.Number, info.Path)
+			}
+		})
+	}
+}
+
+func TestAccountIDsOrderedByNumber(t *testing.T) {
+	t.Parallel()
+
+	coa := NewChartOfAccounts(Period{},
+		NewAccount(1, NewAccountLabel("400", "Koszty", ""), Costs, AllValid(),
+			NewAccount(1, NewAccountLabel("02", "Usługi", ""), Costs, AllValid()),
+			NewAccount(2, NewAccountLabel("01", "Materiały", ""), Costs, AllValid()),
+		),
+		NewAccount(2, NewAccountLabel("130", "Bank", ""), Assets, AllValid()),
+	)
+
+	ids := make([]string, 0)
+	for _, accountID := range coa.AccountIDs() {
+		ids = append(ids, accountID.String())
+	}
+	if expected := "2 1 1-2 1-1"; strings.Join(ids, " ") != expected {
+		t.Fatalf("expected %s, got %s", expected, strings.Join(ids, " "))
+	}
+}
+
+func TestAccountNumbersPanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{
+			name: "duplicated number",
+			f: func() {
+				NewChartOfAccounts(Period{},
+					NewAccount(1, NewAccountLabel("100", "Koszty", ""), Costs, AllValid()),
+					NewAccount(2, NewAccountLabel("100", "Bank", ""), Assets, AllValid()),
+				)
+			},
+		},
+		{
+			name: "number with separator",
+			f:    func() { NewAccountLabel("100-01", "Koszty", "") },
+		},
+		{
+			name: "empty name",
+			f:    func() { NewAccountLabel("100", "", "") },
+		},
+		{
+			name: "unknown number",
+			f:    func() { testChart().AccountIDByNumber("100-03") },
+		},
+		{
+			name: "unknown path",
+			f:    func() { testChart().AccountIDByPath("Koszty", "Paliwo") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, tt.f)
+		})
+	}
+}
+
+func balancedChart() *ChartOfAccounts {
+	return NewChartOfAccounts(Period{Start: day(2025, time.January, 1), End: day(2025, time.December, 31)},
+		NewAccount(1, NewAccountLabel("130", "Bank", ""), Assets, AllValid()),
+		NewAccount(2, NewAccountLabel("200", "Rozrachunki", ""), Liabilities, AllValid()),
+		NewAccount(3, NewAccountLabel("800", "Fundusz", ""), Liabilities, AllValid()),
+		NewAccount(4, NewAccountLabel("400", "Koszty", ""), Costs, AllValid()),
+		OffBalance(NewAccount(5, NewAccountLabel("990", "Pozabilansowe", ""), Costs, AllValid())),
+	)
+}
+
+var balancedMode = BalancedMode{
+	Bank:         NewAccountID(1),
+	Settlements:  NewAccountID(2),
+	Fund:         NewAccountID(3),
+	FixedAssets:  NewAccountID(1),
+	Depreciation: NewAccountID(3),
+}
+
+func TestBalancedMode(t *testing.T) {
+	t.Parallel()
+
+	march := day(2025, time.March, 1)
+	costs := NewAccountID(4)
+	offBalance := NewAccountID(5)
+
+	type violation struct {
+		debit  string
+		credit string
+	}
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		records    [][]EntryRecord
+		violations []violation
+	}{
+		{
+			name: "disabled",
+			records: [][]EntryRecord{
+				{NewEntryRecord(costs, DebitBalance(denom("100.00")))},
+			},
+		},
+		{
+			name:    "balanced entries",
+			enabled: true,
+			records: [][]EntryRecord{
+				{
+					NewEntryRecord(costs, DebitBalance(denom("100.00"))),
+					NewEntryRecord(balancedMode.Settlements, CreditBalance(denom("100.00"))),
+				},
+				{
+					NewEntryRecord(costs, DebitBalance(denom("50.00"))),
+					NewEntryRecord(balancedMode.Settlements, CreditBalance(denom("30.00"))),
+					NewEntryRecord(balancedMode.Bank, CreditBalance(denom("20.00"))),
+				},
+			},
+		},
+		{
+			name:    "off-balance records ignored",
+			enabled: true,
+			records: [][]EntryRecord{
+				{
+					NewEntryRecord(costs, DebitBalance(denom("100.00"))),
+					NewEntryRecord(offBalance, DebitBalance(denom("100.00"))),
+					NewEntryRecord(balancedMode.Settlements, CreditBalance(denom("100.00"))),
+				},
+			},
+		},
+		{
+			name:    "unbalanced entry",
+			enabled: true,
+			records: [][]EntryRecord{
+				{
+					NewEntryRecord(costs, DebitBalance(denom("100.00"))),
+					NewEntryRecord(balancedMode.Settlements, CreditBalance(denom("100.00"))),
+				},
+				{
+					NewEntryRecord(costs, DebitBalance(denom("100.00"))),
+					NewEntryRecord(balancedMode.Settlements, CreditBalance(denom("60.00"))),
+				},
+			},
+			violations: []violation{{debit: "100.00", credit: "60.00"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := balancedChart()
+			if tt.enabled {
+				coa.EnableBalancedMode(balancedMode)
+			}
+			if _, enabled := coa.BalancedMode(); enabled != tt.enabled {
+				t.Fatalf("expected balanced mode %t", tt.enabled)
+			}
+			for _, records := range tt.records {
+				coa.AddEntry(&testEntrySource{date: march}, records...)
+			}
+
+			violations := coa.Violations()
+			if len(violations) != len(tt.violations) {
+				t.Fatalf("expected %d violations, got %d", len(tt.violations), len(violations))
+			}
+			for i, v := range violations {
+				if v.Debit.NEQ(denom(tt.violations[i].debit)) || v.Credit.NEQ(denom(tt.violations[i].credit)) {
+					t.Errorf("violation %d: expected %s/%s, got %s/%s", i, tt.violations[i].debit,
+						tt.violations[i].credit, v.Debit.Amount, v.Credit.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestBookBankRecord(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		amount      string
+		bank        string
+		settlements string
+	}{
+		{name: "disabled", amount: "100.00", bank: "0", settlements: "0"},
+		{name: "incoming payment", enabled: true, amount: "100.00", bank: "100.00", settlements: "100.00"},
+		{name: "outgoing payment", enabled: true, amount: "-40.00", bank: "-40.00", settlements: "-40.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := balancedChart()
+			if tt.enabled {
+				coa.EnableBalancedMode(balancedMode)
+			}
+			date := day(2025, time.March, 1)
+			coa.BookBankRecord(&testEntrySource{date: date}, &BankRecord{Date: date, BaseAmount: denom(tt.amount)})
+
+			if balance := coa.Balance(balancedMode.Bank); balance.NEQ(denom(tt.bank)) {
+				t.Errorf("bank: expected %s, got %s", tt.bank, balance.Amount)
+			}
+			if balance := coa.Balance(balancedMode.Settlements); balance.NEQ(denom(tt.settlements)) {
+				t.Errorf("settlements: expected %s, got %s", tt.settlements, balance.Amount)
+			}
+			if len(coa.Violations()) != 0 {
+				t.Error("unexpected violations")
+			}
+		})
+	}
+}
+
+func TestEnableBalancedModePanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		f    func(coa *ChartOfAccounts)
+	}{
+		{
+			name: "after booking",
+			f: func(coa *ChartOfAccounts) {
+				coa.AddEntry(&testEntrySource{date: day(2025, time.March, 1)},
+					NewEntryRecord(NewAccountID(4), DebitBalance(denom("1.00"))))
+				coa.EnableBalancedMode(balancedMode)
+			},
+		},
+		{
+			name: "off-balance account",
+			f: func(coa *ChartOfAccounts) {
+				mode := balancedMode
+				mode.Fund = NewAccountID(5)
+				coa.EnableBalancedMode(mode)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := balancedChart()
+			assertPanics(t, func() { tt.f(coa) })
+		})
+	}
+}
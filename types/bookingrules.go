@@ -0,0 +1,51 @@
+package types
+
+import "reflect"
+
+// BookingRule redirects records booked by the matching data sources from one account to another, so operations might
+// be booked on user-defined accounts. Rules are applied in order and the first matching one is used.
+type BookingRule struct {
+	Name       string
+	Sources    []reflect.Type
+	From       AccountID
+	To         AccountID
+	Dimensions []Dimension
+}
+
+func (br BookingRule) matches(data EntryDataSource, accountID AccountID, dimensions Dimensions) bool {
+	if !accountIDsEqual(br.From, accountID) {
+		return false
+	}
+	if br.Sources != nil {
+		var found bool
+		dataType := reflect.TypeOf(data)
+		for _, sourceType := range br.Sources {
+			if sourceType == dataType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return dimensions.Match(br.Dimensions...)
+}
+
+// ChartExtension defines user-defined account added to the chart under the parent one.
+type ChartExtension struct {
+	Parent  AccountID
+	Account *Account
+}
+
+func accountIDsEqual(id1, id2 AccountID) bool {
+	if len(id1) != len(id2) {
+		return false
+	}
+	for i := range id1 {
+		if id1[i] != id2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,173 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+type testEntrySource struct {
+	date time.Time
+}
+
+func (s *testEntrySource) GetDate() time.Time {
+	return s.date
+}
+
+func (s *testEntrySource) GetDocument() Document {
+	return Document{ID: "PK/1", Date: s.date}
+}
+
+func (s *testEntrySource) GetContractor() Contractor {
+	return Contractor{}
+}
+
+func (s *testEntrySource) GetNotes() string {
+	return ""
+}
+
+var (
+	materials = NewAccountID(1, 2)
+	services  = NewAccountID(1, 3)
+	fuel      = NewAccountID(1, 4)
+	incomes   = NewAccountID(5)
+)
+
+func rulesChart() *ChartOfAccounts {
+	return NewChartOfAccounts(Period{Start: day(2025, time.January, 1), End: day(2025, time.December, 31)},
+		NewAccount(1, NewAccountLabel("400", "Koszty", ""), Costs, AllValid(),
+			NewAccount(2, NewAccountLabel("01", "Materiały", ""), Costs, AllValid()),
+			NewAccount(3, NewAccountLabel("02", "Usługi", ""), Costs, AllValid()),
+			NewAccount(4, NewAccountLabel("03", "Paliwo", ""), Costs, ValidSources(&testSettlementSource{})),
+		),
+		NewAccount(5, NewAccountLabel("700", "Przychody", ""), Incomes, AllValid()),
+	)
+}
+
+func TestBookingRules(t *testing.T) {
+	t.Parallel()
+
+	projectA := Dimension{Key: DimensionProject, Value: "A"}
+	march := day(2025, time.March, 1)
+
+	tests := []struct {
+		name       string
+		rules      []BookingRule
+		source     EntryDataSource
+		dimensions []Dimension
+		expected   AccountID
+	}{
+		{
+			name:     "no rules",
+			source:   &testEntrySource{date: march},
+			expected: materials,
+		},
+		{
+			name:     "redirected",
+			rules:    []BookingRule{{From: materials, To: services}},
+			source:   &testEntrySource{date: march},
+			expected: services,
+		},
+		{
+			name: "first matching rule applied",
+			rules: []BookingRule{
+				{From: materials, To: services},
+				{From: materials, To: fuel, Sources: ValidSources(&testSettlementSource{})},
+			},
+			source:   &testSettlementSource{date: march},
+			expected: services,
+		},
+		{
+			name:       "matching dimensions",
+			rules:      []BookingRule{{From: materials, To: services, Dimensions: []Dimension{projectA}}},
+			source:     &testEntrySource{date: march},
+			dimensions: []Dimension{projectA},
+			expected:   services,
+		},
+		{
+			name:       "other dimensions",
+			rules:      []BookingRule{{From: materials, To: services, Dimensions: []Dimension{projectA}}},
+			source:     &testEntrySource{date: march},
+			dimensions: []Dimension{{Key: DimensionProject, Value: "B"}},
+			expected:   materials,
+		},
+		{
+			name: "matching source",
+			rules: []BookingRule{{
+				From:    materials,
+				To:      fuel,
+				Sources: ValidSources(&testSettlementSource{}),
+			}},
+			source:   &testSettlementSource{date: march},
+			expected: fuel,
+		},
+		{
+			name: "other source",
+			rules: []BookingRule{{
+				From:    materials,
+				To:      fuel,
+				Sources: ValidSources(&testSettlementSource{}),
+			}},
+			source:   &testEntrySource{date: march},
+			expected: materials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := rulesChart()
+			coa.AddBookingRules(tt.rules...)
+			coa.AddEntry(tt.source, NewEntryRecord(materials, DebitBalance(denom("100.00"))).
+				WithDimensions(tt.dimensions...))
+
+			for _, accountID := range []AccountID{materials, services, fuel} {
+				expected := "0"
+				if accountID.String() == tt.expected.String() {
+					expected = "100.00"
+				}
+				if balance := coa.Balance(accountID); balance.NEQ(denom(expected)) {
+					t.Errorf("account %s: expected %s, got %s", accountID, expected, balance.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestAddBookingRulesPanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule BookingRule
+	}{
+		{
+			name: "target is not a leaf",
+			rule: BookingRule{From: materials, To: NewAccountID(1)},
+		},
+		{
+			name: "unknown target",
+			rule: BookingRule{From: materials, To: NewAccountID(1, 9)},
+		},
+		{
+			name: "debit not allowed on target",
+			rule: BookingRule{From: materials, To: incomes},
+		},
+		{
+			name: "target does not accept all sources",
+			rule: BookingRule{From: materials, To: fuel},
+		},
+		{
+			name: "target does not accept the source",
+			rule: BookingRule{From: materials, To: fuel, Sources: ValidSources(&testEntrySource{})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, func() { rulesChart().AddBookingRules(tt.rule) })
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// Budget is the yearly budget approved by the board.
+type Budget []BudgetLine
+
+// BudgetLine defines monthly budgeted amounts on the account, optionally limited to dimensions.
+type BudgetLine struct {
+	Name       string
+	AccountID  AccountID
+	Dimensions []Dimension
+	Amounts    map[time.Month]Denom
+}
+
+// Month returns amount budgeted for the month.
+func (bl BudgetLine) Month(month time.Month) Denom {
+	amount, exists := bl.Amounts[month]
+	if !exists {
+		return BaseZero
+	}
+	return amount
+}
+
+// UntilMonth returns amount budgeted from the beginning of the year until the end of the month.
+func (bl BudgetLine) UntilMonth(month time.Month) Denom {
+	amount := BaseZero
+	for m := time.January; m <= month; m++ {
+		amount = amount.Add(bl.Month(m))
+	}
+	return amount
+}
+
+// Total returns amount budgeted for the whole year.
+func (bl BudgetLine) Total() Denom {
+	return bl.UntilMonth(time.December)
+}
@@ -0,0 +1,266 @@
+package types
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Key returns the key identifying the contractor in settlements. Tax ID is used if defined, name otherwise.
+func (c Contractor) Key() string {
+	if c.TaxID != "" {
+		return c.TaxID
+	}
+	return c.Name
+}
+
+// SettlementSource is implemented by operations creating receivables or payables towards the contractor.
+type SettlementSource interface {
+	EntryDataSource
+
+	// SettlementDues returns dues of the document. Receivables are positive, payables are negative.
+	SettlementDues() []Due
+
+	// GetCorrectedDocument returns the document corrected by the credit note. It is empty for other documents.
+	GetCorrectedDocument() DocumentID
+}
+
+// SettlementEntryType defines type of the entry in settlements with the contractor.
+type SettlementEntryType uint8
+
+// Settlement entry types.
+const (
+	SettlementInvoice SettlementEntryType = iota
+	SettlementCreditNote
+	SettlementPayment
+)
+
+// SettlementEntry is the change of settlements with the contractor. Positive amounts increase receivables or decrease
+// payables, negative ones do the opposite. Payable is set if the settled document is the payable.
+type SettlementEntry struct {
+	Type       SettlementEntryType
+	Date       time.Time
+	Document   DocumentID
+	Settled    DocumentID
+	Payable    bool
+	Notes      string
+	Dues       []Due
+	Amount     Denom
+	BaseAmount Denom
+}
+
+// OpenItem is the document which is not settled yet.
+type OpenItem struct {
+	Contractor    Contractor
+	Document      DocumentID
+	Date          time.Time
+	DueDate       time.Time
+	Amount        Denom
+	Remaining     Denom
+	BaseRemaining Denom
+}
+
+// IsReceivable returns true if open item is the receivable, false if it is the payable.
+func (oi OpenItem) IsReceivable() bool {
+	return oi.Remaining.GT(NewDenom(oi.Remaining.Currency))
+}
+
+// ContractorLedger stores settlements with the contractor.
+type ContractorLedger struct {
+	Contractor Contractor
+	Entries    []SettlementEntry
+}
+
+// NewContractorLedgers builds ledgers of settlements with contractors from operations and their bank records.
+func NewContractorLedgers(
+	operations []Operation,
+	bankRecords map[Operation][]*BankRecord,
+	rates CurrencyRates,
+) []*ContractorLedger {
+	ledgers := map[string]*ContractorLedger{}
+	for _, op := range operations {
+		source, ok := op.(SettlementSource)
+		if !ok {
+			continue
+		}
+		dues := source.SettlementDues()
+		if len(dues) == 0 {
+			// Fixed assets carried forward from previous years are not settled in the fiscal year.
+			continue
+		}
+
+		contractor := source.GetContractor()
+		ledger, exists := ledgers[contractor.Key()]
+		if !exists {
+			ledger = &ContractorLedger{
+				Contractor: contractor,
+			}
+			ledgers[contractor.Key()] = ledger
+		}
+
+		document := source.GetDocument()
+		entryType := SettlementInvoice
+		settled := document.ID
+		if corrected := source.GetCorrectedDocument(); corrected != "" {
+			entryType = SettlementCreditNote
+			settled = corrected
+		}
+
+		amount := dues[0].Amount
+		for _, d := range dues[1:] {
+			amount = amount.Add(d.Amount)
+		}
+		baseAmount, _ := rates.ToBase(amount, PreviousDay(source.GetDate()))
+		// Credit notes have the opposite sign of dues than the documents they correct.
+		payable := amount.LT(NewDenom(amount.Currency)) != (entryType == SettlementCreditNote)
+
+		ledger.Entries = append(ledger.Entries, SettlementEntry{
+			Type:       entryType,
+			Date:       source.GetDate(),
+			Document:   document.ID,
+			Settled:    settled,
+			Payable:    payable,
+			Notes:      source.GetNotes(),
+			Dues:       append([]Due{}, dues...),
+			Amount:     amount,
+			BaseAmount: baseAmount,
+		})
+		for _, br := range bankRecords[op] {
+			ledger.Entries = append(ledger.Entries, SettlementEntry{
+				Type:       SettlementPayment,
+				Date:       br.Date,
+				Document:   br.Document,
+				Settled:    settled,
+				Payable:    payable,
+				Notes:      source.GetNotes(),
+				Amount:     br.OriginalAmount.Neg(),
+				BaseAmount: br.BaseAmount.Neg(),
+			})
+		}
+	}
+
+	result := make([]*ContractorLedger, 0, len(ledgers))
+	for _, ledger := range ledgers {
+		sort.SliceStable(ledger.Entries, func(i, j int) bool {
+			return ledger.Entries[i].Date.Before(ledger.Entries[j].Date)
+		})
+		result = append(result, ledger)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Contractor.Name != result[j].Contractor.Name {
+			return strings.Compare(result[i].Contractor.Name, result[j].Contractor.Name) < 0
+		}
+		return strings.Compare(result[i].Contractor.Key(), result[j].Contractor.Key()) < 0
+	})
+	return result
+}
+
+// Currencies returns currencies used in settlements with the contractor.
+func (l *ContractorLedger) Currencies() []CurrencySymbol {
+	currencies := []CurrencySymbol{}
+	for _, e := range l.Entries {
+		exists := false
+		for _, c := range currencies {
+			if c == e.Amount.Currency {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			currencies = append(currencies, e.Amount.Currency)
+		}
+	}
+	sort.Slice(currencies, func(i, j int) bool {
+		return strings.Compare(string(currencies[i]), string(currencies[j])) < 0
+	})
+	return currencies
+}
+
+// Balance returns balance of settlements in the currency as of the date, in original and base currency. Positive
+// balance is the receivable, negative one is the payable.
+func (l *ContractorLedger) Balance(currency CurrencySymbol, date time.Time) (Denom, Denom) {
+	balance := NewDenom(currency)
+	baseBalance := BaseZero
+	for _, e := range l.Entries {
+		if e.Date.After(date) {
+			break
+		}
+		if e.Amount.Currency != currency {
+			continue
+		}
+		balance = balance.Add(e.Amount)
+		baseBalance = baseBalance.Add(e.BaseAmount)
+	}
+	return balance, baseBalance
+}
+
+// OpenItems returns documents which are not settled as of the date. Credit notes and payments are settled against
+// the documents they relate to. Receivables and payables having the same document ID are settled separately.
+func (l *ContractorLedger) OpenItems(date time.Time) []OpenItem {
+	type itemKey struct {
+		Document DocumentID
+		Payable  bool
+	}
+
+	items := map[itemKey]*OpenItem{}
+	dues := map[itemKey][]Due{}
+	order := []itemKey{}
+	for _, e := range l.Entries {
+		if e.Date.After(date) {
+			break
+		}
+
+		key := itemKey{Document: e.Settled, Payable: e.Payable}
+		item, exists := items[key]
+		if !exists {
+			item = &OpenItem{
+				Contractor:    l.Contractor,
+				Document:      e.Settled,
+				Date:          e.Date,
+				Amount:        NewDenom(e.Amount.Currency),
+				Remaining:     NewDenom(e.Amount.Currency),
+				BaseRemaining: BaseZero,
+			}
+			items[key] = item
+			order = append(order, key)
+		}
+		switch e.Type {
+		case SettlementInvoice:
+			item.Date = e.Date
+			item.Amount = item.Amount.Add(e.Amount)
+			dues[key] = e.Dues
+		case SettlementCreditNote:
+			item.Amount = item.Amount.Add(e.Amount)
+			if _, exists := dues[key]; !exists {
+				dues[key] = e.Dues
+			}
+		}
+		item.Remaining = item.Remaining.Add(e.Amount)
+		item.BaseRemaining = item.BaseRemaining.Add(e.BaseAmount)
+	}
+
+	result := []OpenItem{}
+	for _, key := range order {
+		item := items[key]
+		if item.Remaining.Amount.IsZero() {
+			continue
+		}
+
+		documentDues := append([]Due{}, dues[key]...)
+		sort.SliceStable(documentDues, func(i, j int) bool {
+			return documentDues[i].Date.Before(documentDues[j].Date)
+		})
+		covered := item.Amount.Sub(item.Remaining).Abs()
+		cumulative := NewDenom(item.Amount.Currency)
+		for _, d := range documentDues {
+			item.DueDate = d.Date
+			cumulative = cumulative.Add(d.Amount.Abs())
+			if cumulative.GT(covered) {
+				break
+			}
+		}
+
+		result = append(result, *item)
+	}
+	return result
+}
@@ -0,0 +1,243 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+type testSettlementSource struct {
+	date      time.Time
+	id        DocumentID
+	corrected DocumentID
+	dues      []Due
+}
+
+func (s *testSettlementSource) BankRecords() []*BankRecord {
+	return nil
+}
+
+func (s *testSettlementSource) BookRecords(
+	period Period,
+	coa *ChartOfAccounts,
+	bankRecords []*BankRecord,
+	rates CurrencyRates,
+) []ReportDocument {
+	return nil
+}
+
+func (s *testSettlementSource) GetDate() time.Time {
+	return s.date
+}
+
+func (s *testSettlementSource) GetDocument() Document {
+	return Document{ID: s.id, Date: s.date}
+}
+
+func (s *testSettlementSource) GetContractor() Contractor {
+	return Contractor{Name: "Żaglownia", TaxID: "5861234567"}
+}
+
+func (s *testSettlementSource) GetNotes() string {
+	return ""
+}
+
+func (s *testSettlementSource) SettlementDues() []Due {
+	return s.dues
+}
+
+func (s *testSettlementSource) GetCorrectedDocument() DocumentID {
+	return s.corrected
+}
+
+func due(date time.Time, amount string) Due {
+	return Due{Date: date, Amount: denom(amount)}
+}
+
+func payment(date time.Time, amount string) *BankRecord {
+	return &BankRecord{
+		Date:           date,
+		Document:       "WB/1",
+		OriginalAmount: denom(amount),
+		BaseAmount:     denom(amount),
+	}
+}
+
+func TestOpenItems(t *testing.T) {
+	t.Parallel()
+
+	type expectedItem struct {
+		document  DocumentID
+		dueDate   time.Time
+		remaining string
+	}
+
+	tests := []struct {
+		name        string
+		operations  []*testSettlementSource
+		bankRecords map[int][]*BankRecord
+		date        time.Time
+		expected    []expectedItem
+	}{
+		{
+			name: "partially paid receivable",
+			operations: []*testSettlementSource{
+				{
+					date: day(2025, time.January, 10),
+					id:   "FS/1",
+					dues: []Due{
+						due(day(2025, time.March, 10), "60.00"),
+						due(day(2025, time.February, 10), "40.00"),
+					},
+				},
+			},
+			bankRecords: map[int][]*BankRecord{
+				0: {payment(day(2025, time.February, 12), "50.00")},
+			},
+			date: day(2025, time.March, 31),
+			expected: []expectedItem{
+				{document: "FS/1", dueDate: day(2025, time.March, 10), remaining: "50.00"},
+			},
+		},
+		{
+			name: "payment after the date",
+			operations: []*testSettlementSource{
+				{
+					date: day(2025, time.January, 10),
+					id:   "FS/1",
+					dues: []Due{due(day(2025, time.February, 10), "100.00")},
+				},
+			},
+			bankRecords: map[int][]*BankRecord{
+				0: {payment(day(2025, time.February, 12), "100.00")},
+			},
+			date: day(2025, time.February, 11),
+			expected: []expectedItem{
+				{document: "FS/1", dueDate: day(2025, time.February, 10), remaining: "100.00"},
+			},
+		},
+		{
+			name: "credit note settles receivable",
+			operations: []*testSettlementSource{
+				{
+					date: day(2025, time.January, 10),
+					id:   "FS/1",
+					dues: []Due{due(day(2025, time.February, 10), "100.00")},
+				},
+				{
+					date:      day(2025, time.January, 20),
+					id:        "FK/1",
+					corrected: "FS/1",
+					dues:      []Due{due(day(2025, time.February, 10), "-30.00")},
+				},
+			},
+			bankRecords: map[int][]*BankRecord{
+				0: {payment(day(2025, time.February, 12), "70.00")},
+			},
+			date: day(2025, time.March, 31),
+		},
+		{
+			name: "receivable and payable with the same document ID",
+			operations: []*testSettlementSource{
+				{
+					date: day(2025, time.January, 10),
+					id:   "1/2025",
+					dues: []Due{due(day(2025, time.February, 10), "100.00")},
+				},
+				{
+					date: day(2025, time.January, 15),
+					id:   "1/2025",
+					dues: []Due{due(day(2025, time.February, 15), "-80.00")},
+				},
+			},
+			bankRecords: map[int][]*BankRecord{
+				1: {payment(day(2025, time.February, 15), "-80.00")},
+			},
+			date: day(2025, time.March, 31),
+			expected: []expectedItem{
+				{document: "1/2025", dueDate: day(2025, time.February, 10), remaining: "100.00"},
+			},
+		},
+		{
+			name: "unpaid receivable and payable with the same document ID",
+			operations: []*testSettlementSource{
+				{
+					date: day(2025, time.January, 10),
+					id:   "1/2025",
+					dues: []Due{due(day(2025, time.February, 10), "100.00")},
+				},
+				{
+					date: day(2025, time.January, 15),
+					id:   "1/2025",
+					dues: []Due{due(day(2025, time.February, 15), "-80.00")},
+				},
+			},
+			date: day(2025, time.March, 31),
+			expected: []expectedItem{
+				{document: "1/2025", dueDate: day(2025, time.February, 10), remaining: "100.00"},
+				{document: "1/2025", dueDate: day(2025, time.February, 15), remaining: "-80.00"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			operations := make([]Operation, 0, len(tt.operations))
+			bankRecords := map[Operation][]*BankRecord{}
+			for i, op := range tt.operations {
+				operations = append(operations, op)
+				bankRecords[op] = tt.bankRecords[i]
+			}
+
+			ledgers := NewContractorLedgers(operations, bankRecords, nil)
+			if len(ledgers) != 1 {
+				t.Fatalf("expected 1 ledger, got %d", len(ledgers))
+			}
+			items := ledgers[0].OpenItems(tt.date)
+			if len(items) != len(tt.expected) {
+				t.Fatalf("expected %d open items, got %d", len(tt.expected), len(items))
+			}
+			for i, item := range items {
+				e := tt.expected[i]
+				if item.Document != e.document || !item.DueDate.Equal(e.dueDate) ||
+					item.Remaining.NEQ(denom(e.remaining)) {
+					t.Errorf("item %d: expected %s due %s remaining %s, got %s due %s remaining %s", i, e.document,
+						e.dueDate.Format(time.DateOnly), e.remaining, item.Document,
+						item.DueDate.Format(time.DateOnly), item.Remaining.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenItemsKeepSourceDues(t *testing.T) {
+	t.Parallel()
+
+	dues := []Due{
+		due(day(2025, time.March, 10), "60.00"),
+		due(day(2025, time.February, 10), "40.00"),
+	}
+	ledger := &ContractorLedger{
+		Contractor: Contractor{Name: "Żaglownia"},
+		Entries: []SettlementEntry{
+			{
+				Type:       SettlementInvoice,
+				Date:       day(2025, time.January, 10),
+				Document:   "FS/1",
+				Settled:    "FS/1",
+				Dues:       dues,
+				Amount:     denom("100.00"),
+				BaseAmount: denom("100.00"),
+			},
+		},
+	}
+
+	items := ledger.OpenItems(day(2025, time.March, 31))
+	if len(items) != 1 || !items[0].DueDate.Equal(day(2025, time.February, 10)) {
+		t.Fatalf("unexpected open items %v", items)
+	}
+	if !dues[0].Date.Equal(day(2025, time.March, 10)) || !dues[1].Date.Equal(day(2025, time.February, 10)) {
+		t.Error("dues of the entry were reordered")
+	}
+}
@@ -57,6 +57,25 @@ func NewDenom(currency CurrencySymbol) Denom {
 	}
 }
 
+// ParseDenom parses the decimal amount of the currency, e.g. "123.45".
+func ParseDenom(amount string, currency CurrencySymbol) (Denom, error) {
+	c, exists := Currencies[currency]
+	if !exists {
+		return Denom{}, errors.Errorf("unknown currency '%s'", currency)
+	}
+	dec, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Denom{}, errors.Wrapf(err, "invalid amount '%s'", amount)
+	}
+	if !dec.Equal(dec.Round(int32(c.AmountPrecision))) {
+		return Denom{}, errors.Errorf("amount '%s' exceeds precision of %s", amount, currency)
+	}
+	return Denom{
+		Currency: currency,
+		Amount:   newNumberFromDecimal(dec, c.AmountPrecision),
+	}, nil
+}
+
 // Denom is the amount of currency.
 type Denom struct {
 	Currency CurrencySymbol
@@ -145,6 +164,125 @@ func (d Denom) Abs() Denom {
 	}
 }
 
+// Percent returns the percentage of denom, rounded to the precision of the currency.
+func (d Denom) Percent(percent Number) Denom {
+	return Denom{
+		Currency: d.Currency,
+		Amount: newNumberFromDecimal(
+			d.Amount.decimal.Mul(percent.decimal).DivRound(decimal.New(100, 0), int32(d.Amount.precision)),
+			d.Amount.precision,
+		),
+	}
+}
+
+// Div divides denom by integer divisor, rounding the result to the precision of the currency.
+func (d Denom) Div(divisor uint64) Denom {
+	if divisor == 0 {
+		panic("division by zero")
+	}
+	return Denom{
+		Currency: d.Currency,
+		Amount: newNumberFromDecimal(
+			d.Amount.decimal.DivRound(decimal.New(int64(divisor), 0), int32(d.Amount.precision)),
+			d.Amount.precision,
+		),
+	}
+}
+
+// Mul multiplies denom by integer factor.
+func (d Denom) Mul(factor uint64) Denom {
+	return Denom{
+		Currency: d.Currency,
+		Amount:   d.Amount.Mul(factor),
+	}
+}
+
+// Interest returns simple interest on denom for the number of days at the annual rate given in percents, rounded to
+// the precision of the currency.
+func (d Denom) Interest(annualRate Number, days uint64) Denom {
+	return Denom{
+		Currency: d.Currency,
+		Amount: newNumberFromDecimal(
+			d.Amount.decimal.Mul(annualRate.decimal).Mul(decimal.New(int64(days), 0)).
+				DivRound(decimal.New(100*365, 0), int32(d.Amount.precision)),
+			d.Amount.precision,
+		),
+	}
+}
+
+// PercentOf returns the percentage which denom is of the total.
+func (d Denom) PercentOf(total Denom) Number {
+	if d.Currency != total.Currency {
+		panic("currency mismatch")
+	}
+	if total.Amount.IsZero() {
+		panic("total is zero")
+	}
+	return newNumberFromDecimal(
+		d.Amount.decimal.Mul(decimal.New(100, 0)).DivRound(total.Amount.decimal, PercentPrecision),
+		PercentPrecision,
+	)
+}
+
+// RoundUnits rounds denom to full currency units.
+func (d Denom) RoundUnits() Denom {
+	return Denom{
+		Currency: d.Currency,
+		Amount:   newNumberFromDecimal(d.Amount.decimal.Round(0), d.Amount.precision),
+	}
+}
+
+// Min returns the smaller of two denoms.
+func (d Denom) Min(denom Denom) Denom {
+	if d.LT(denom) {
+		return d
+	}
+	return denom
+}
+
+// Allocate splits denom into parts proportional to weights. Rounding difference is assigned to the part having the
+// largest weight (the last one if there are many), so the sum of parts is always equal to the original denom.
+func (d Denom) Allocate(weights ...Number) []Denom {
+	if len(weights) == 0 {
+		panic("no weights")
+	}
+
+	total := decimal.Zero
+	largest := 0
+	for i, w := range weights {
+		if w.decimal.IsNegative() {
+			panic("negative weight")
+		}
+		if w.decimal.GreaterThanOrEqual(weights[largest].decimal) {
+			largest = i
+		}
+		total = total.Add(w.decimal)
+	}
+	if total.IsZero() {
+		panic("weights sum to zero")
+	}
+
+	parts := make([]Denom, 0, len(weights))
+	rest := d
+	for i, w := range weights {
+		if i == largest {
+			parts = append(parts, Denom{})
+			continue
+		}
+		part := Denom{
+			Currency: d.Currency,
+			Amount: newNumberFromDecimal(
+				d.Amount.decimal.Mul(w.decimal).DivRound(total, int32(d.Amount.precision)),
+				d.Amount.precision,
+			),
+		}
+		parts = append(parts, part)
+		rest = rest.Sub(part)
+	}
+	parts[largest] = rest
+	return parts
+}
+
 // ToBase converts denom to the base currency.
 func (d Denom) ToBase(rate Number) Denom {
 	currency := Currencies.Currency(d.Currency)
@@ -175,6 +313,14 @@ func (d Denom) Rate(denom Denom) Number {
 		currency.RatePrecision)
 }
 
+// PercentPrecision is the precision used by percentages.
+const PercentPrecision = 2
+
+// NewPercent creates new percentage.
+func NewPercent(i, d uint64) Number {
+	return NewNumber(i, d, PercentPrecision)
+}
+
 // NewNumber creates new number.
 func NewNumber(i, d, precision uint64) Number {
 	dec := decimal.New(int64(d), int32(-precision))
@@ -239,6 +385,11 @@ func (n Number) LTE(n2 Number) bool {
 	return !n.GT(n2)
 }
 
+// Mul multiplies number by integer factor.
+func (n Number) Mul(factor uint64) Number {
+	return newNumberFromDecimal(n.decimal.Mul(decimal.New(int64(factor), 0)), n.precision)
+}
+
 // Add adds numbers.
 func (n Number) Add(n2 Number) Number {
 	return newNumberFromDecimal(n.decimal.Add(n2.decimal), n.precision)
@@ -291,6 +442,29 @@ func (cr CurrencyRates) ToBase(denom Denom, date time.Time) (Denom, Number) {
 	return denom.ToBase(rate), rate
 }
 
+// ToBaseLatest converts denom to the base currency using the latest rate published not later than the date.
+func (cr CurrencyRates) ToBaseLatest(denom Denom, date time.Time) (Denom, Number) {
+	if denom.Currency == PLN {
+		return cr.ToBase(denom, date)
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	for range maxRateGap {
+		rate, exists := cr[CurrencyRateKey{
+			Currency: denom.Currency,
+			Date:     day,
+		}]
+		if exists {
+			return denom.ToBase(rate), rate
+		}
+		day = PreviousDay(day)
+	}
+	panic(errors.Errorf("no rate for %s published before %s", denom.Currency, date))
+}
+
+// maxRateGap is the maximum number of days without published rates.
+const maxRateGap = 14
+
 func (cr CurrencyRates) rate(currency CurrencySymbol, date time.Time) Number {
 	if currency == PLN {
 		return Number{
@@ -0,0 +1,128 @@
+package types
+
+import "testing"
+
+func denom(amount string) Denom {
+	d, err := ParseDenom(amount, PLN)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func weights(ws ...uint64) []Number {
+	numbers := make([]Number, 0, len(ws))
+	for _, w := range ws {
+		numbers = append(numbers, NewNumber(w, 0, 0))
+	}
+	return numbers
+}
+
+func assertDenoms(t *testing.T, expected []string, parts []Denom) {
+	t.Helper()
+
+	if len(parts) != len(expected) {
+		t.Fatalf("expected %d parts, got %d", len(expected), len(parts))
+	}
+	for i, e := range expected {
+		if parts[i].NEQ(denom(e)) {
+			t.Errorf("part %d: expected %s, got %s", i, e, parts[i].Amount)
+		}
+	}
+}
+
+func assertPanics(t *testing.T, f func()) {
+	t.Helper()
+
+	defer func() {
+		if recover() == nil {
+			t.Error("panic expected")
+		}
+	}()
+	f()
+}
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		amount   string
+		weights  []Number
+		expected []string
+	}{
+		{
+			name:     "exact",
+			amount:   "100.00",
+			weights:  weights(1, 2, 1),
+			expected: []string{"25.00", "50.00", "25.00"},
+		},
+		{
+			name:     "remainder to largest part",
+			amount:   "100.00",
+			weights:  weights(1, 1, 4, 1),
+			expected: []string{"14.29", "14.29", "57.13", "14.29"},
+		},
+		{
+			name:     "remainder to last of equal parts",
+			amount:   "100.00",
+			weights:  weights(1, 1, 1),
+			expected: []string{"33.33", "33.33", "33.34"},
+		},
+		{
+			name:     "zero weight",
+			amount:   "0.10",
+			weights:  weights(1, 2, 0),
+			expected: []string{"0.03", "0.07", "0.00"},
+		},
+		{
+			name:     "only one non-zero weight",
+			amount:   "10.00",
+			weights:  weights(0, 5, 0),
+			expected: []string{"0.00", "10.00", "0.00"},
+		},
+		{
+			name:     "negative amount",
+			amount:   "-100.00",
+			weights:  weights(1, 1, 1),
+			expected: []string{"-33.33", "-33.33", "-33.34"},
+		},
+		{
+			name:     "single weight",
+			amount:   "12.34",
+			weights:  weights(7),
+			expected: []string{"12.34"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertDenoms(t, tt.expected, denom(tt.amount).Allocate(tt.weights...))
+		})
+	}
+}
+
+func TestAllocatePanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		weights []Number
+	}{
+		{name: "no weights"},
+		{name: "zero weights", weights: weights(0, 0)},
+		{name: "negative weight", weights: []Number{NewNumber(1, 0, 0), NewNumber(1, 0, 0).Neg()}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, func() {
+				denom("100.00").Allocate(tt.weights...)
+			})
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package types
+
+import "time"
+
+// EquipmentItem defines the item of low-value equipment expensed directly.
+type EquipmentItem struct {
+	InventoryNumber   string
+	Name              string
+	Quantity          uint64
+	Value             Denom
+	Location          string
+	ResponsiblePerson string
+}
+
+// EquipmentDisposal defines the disposal of the equipment item.
+type EquipmentDisposal struct {
+	Date            time.Time
+	Document        Document
+	InventoryNumber string
+	Reason          string
+}
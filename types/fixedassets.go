@@ -0,0 +1,119 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// DepreciationMethod defines the method of fixed asset depreciation.
+type DepreciationMethod string
+
+// Depreciation methods.
+const (
+	DepreciationMethodLinear   DepreciationMethod = "linear"
+	DepreciationMethodOneOff   DepreciationMethod = "oneOff"
+	DepreciationMethodReducing DepreciationMethod = "reducing"
+)
+
+// reducingRateFactor is the factor applied to the rate in the reducing balance method.
+const reducingRateFactor = 2
+
+// LowValueAssetLimit is the maximum initial value of the fixed asset which might be depreciated at once.
+var LowValueAssetLimit = Denom{Currency: PLN, Amount: NewNumber(10000, 0, BaseCurrency.AmountPrecision)}
+
+// FixedAsset defines the fixed asset.
+type FixedAsset struct {
+	InventoryNumber string
+	Name            string
+	KSTGroup        string
+	Date            time.Time
+	Document        Document
+	InitialValue    Denom
+	Method          DepreciationMethod
+	Rate            Number
+	StartMonth      time.Time
+}
+
+// OpeningFixedAsset is the fixed asset acquired before the fiscal year and still depreciated in it.
+type OpeningFixedAsset struct {
+	Asset            FixedAsset
+	CostTaxType      CostTaxType
+	CostCategoryType CostCategoryType
+	Project          Project
+}
+
+// Depreciation is the monthly depreciation write-off.
+type Depreciation struct {
+	Month       time.Time
+	Amount      Denom
+	Accumulated Denom
+	NetValue    Denom
+}
+
+// GetDate returns date of the depreciation write-off, being the end of the month.
+func (d Depreciation) GetDate() time.Time {
+	return d.Month.AddDate(0, 1, 0).Add(-time.Nanosecond)
+}
+
+// DepreciationPlan returns depreciation write-offs for all the months until the asset is fully depreciated.
+func (fa FixedAsset) DepreciationPlan() []Depreciation {
+	month := fa.StartMonth.AddDate(0, 0, -fa.StartMonth.Day()+1)
+	zero := NewDenom(fa.InitialValue.Currency)
+
+	if fa.Method == DepreciationMethodOneOff {
+		if fa.InitialValue.Currency != LowValueAssetLimit.Currency || fa.InitialValue.GT(LowValueAssetLimit) {
+			panic(fmt.Sprintf("one-off depreciation of fixed asset %s exceeds %s", fa.InventoryNumber,
+				LowValueAssetLimit))
+		}
+		return []Depreciation{{
+			Month:       month,
+			Amount:      fa.InitialValue,
+			Accumulated: fa.InitialValue,
+			NetValue:    zero,
+		}}
+	}
+
+	linear := fa.InitialValue.Percent(fa.Rate).Div(12)
+	if !linear.GT(zero) {
+		panic("invalid depreciation rate")
+	}
+
+	var monthly Denom
+	var reducing bool
+	switch fa.Method {
+	case DepreciationMethodLinear:
+		monthly = linear
+	case DepreciationMethodReducing:
+		reducing = true
+	default:
+		panic("invalid depreciation method")
+	}
+
+	plan := []Depreciation{}
+	accumulated := zero
+	netValue := fa.InitialValue
+	for netValue.GT(zero) {
+		// In reducing balance method the write-off is recomputed at the beginning of each year, until it drops below
+		// the linear one.
+		if reducing && (len(plan) == 0 || month.Month() == time.January) {
+			monthly = netValue.Percent(fa.Rate.Mul(reducingRateFactor)).Div(12)
+			if !monthly.GT(linear) {
+				monthly = linear
+				reducing = false
+			}
+		}
+
+		amount := monthly.Min(netValue)
+		accumulated = accumulated.Add(amount)
+		netValue = netValue.Sub(amount)
+		plan = append(plan, Depreciation{
+			Month:       month,
+			Amount:      amount,
+			Accumulated: accumulated,
+			NetValue:    netValue,
+		})
+		month = month.AddDate(0, 1, 0)
+	}
+
+	return plan
+}
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDepreciationPlan(t *testing.T) {
+	t.Parallel()
+
+	type write struct {
+		index    int
+		month    time.Time
+		amount   string
+		netValue string
+	}
+
+	tests := []struct {
+		name   string
+		asset  FixedAsset
+		months int
+		writes []write
+	}{
+		{
+			name: "linear",
+			asset: FixedAsset{
+				InitialValue: denom("12000.00"),
+				Method:       DepreciationMethodLinear,
+				Rate:         NewPercent(20, 0),
+				StartMonth:   day(2025, time.March, 15),
+			},
+			months: 60,
+			writes: []write{
+				{index: 0, month: day(2025, time.March, 1), amount: "200.00", netValue: "11800.00"},
+				{index: 59, month: day(2030, time.February, 1), amount: "200.00", netValue: "0.00"},
+			},
+		},
+		{
+			name: "linear with the last write-off reduced",
+			asset: FixedAsset{
+				InitialValue: denom("1000.00"),
+				Method:       DepreciationMethodLinear,
+				Rate:         NewPercent(7, 0),
+				StartMonth:   day(2025, time.January, 1),
+			},
+			// Monthly write-off is 5.83.
+			months: 172,
+			writes: []write{
+				{index: 0, month: day(2025, time.January, 1), amount: "5.83", netValue: "994.17"},
+				{index: 171, month: day(2039, time.April, 1), amount: "3.07", netValue: "0.00"},
+			},
+		},
+		{
+			name: "reducing switched to linear",
+			asset: FixedAsset{
+				InitialValue: denom("10000.00"),
+				Method:       DepreciationMethodReducing,
+				Rate:         NewPercent(20, 0),
+				StartMonth:   day(2025, time.July, 1),
+			},
+			writes: []write{
+				// Rate doubled to 40% of the initial value in the first year.
+				{index: 0, month: day(2025, time.July, 1), amount: "333.33", netValue: "9666.67"},
+				{index: 5, month: day(2025, time.December, 1), amount: "333.33", netValue: "8000.02"},
+				// Recomputed from the net value at the beginning of the year.
+				{index: 6, month: day(2026, time.January, 1), amount: "266.67", netValue: "7733.35"},
+				// 40% of the net value 4799.98 is less than the linear write-off.
+				{index: 18, month: day(2027, time.January, 1), amount: "166.67", netValue: "4633.31"},
+			},
+		},
+		{
+			name: "one-off",
+			asset: FixedAsset{
+				InitialValue: denom("8000.00"),
+				Method:       DepreciationMethodOneOff,
+				StartMonth:   day(2025, time.May, 20),
+			},
+			months: 1,
+			writes: []write{
+				{index: 0, month: day(2025, time.May, 1), amount: "8000.00", netValue: "0.00"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan := tt.asset.DepreciationPlan()
+			if tt.months != 0 && len(plan) != tt.months {
+				t.Fatalf("expected %d months, got %d", tt.months, len(plan))
+			}
+			last := plan[len(plan)-1]
+			if !last.NetValue.Amount.IsZero() || last.Accumulated.NEQ(tt.asset.InitialValue) {
+				t.Errorf("asset not fully depreciated, net value %s, accumulated %s", last.NetValue.Amount,
+					last.Accumulated.Amount)
+			}
+			for _, w := range tt.writes {
+				d := plan[w.index]
+				if !d.Month.Equal(w.month) || d.Amount.NEQ(denom(w.amount)) || d.NetValue.NEQ(denom(w.netValue)) {
+					t.Errorf("write-off %d: expected %s %s net %s, got %s %s net %s", w.index,
+						w.month.Format(time.DateOnly), w.amount, w.netValue, d.Month.Format(time.DateOnly),
+						d.Amount.Amount, d.NetValue.Amount)
+				}
+			}
+		})
+	}
+}
+
+func TestDepreciationPlanPanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		asset FixedAsset
+	}{
+		{
+			name: "one-off above the limit",
+			asset: FixedAsset{
+				InitialValue: denom("10000.01"),
+				Method:       DepreciationMethodOneOff,
+			},
+		},
+		{
+			name: "zero rate",
+			asset: FixedAsset{
+				InitialValue: denom("1000.00"),
+				Method:       DepreciationMethodLinear,
+			},
+		},
+		{
+			name: "unknown method",
+			asset: FixedAsset{
+				InitialValue: denom("1000.00"),
+				Rate:         NewPercent(20, 0),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, func() { tt.asset.DepreciationPlan() })
+		})
+	}
+}
@@ -4,6 +4,7 @@ package types
 type Init struct {
 	UnspentProfit Denom
 	Currencies    InitCurrencies
+	FixedAssets   []OpeningFixedAsset
 }
 
 // InitCurrencies stores initial sums of currencies.
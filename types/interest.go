@@ -0,0 +1,136 @@
+package types
+
+import (
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// InterestType defines the kind of statutory interest charged on late payments.
+type InterestType uint8
+
+// Interest types.
+const (
+	InterestTypeNone InterestType = iota
+	InterestTypeDelay
+	InterestTypeCommercial
+)
+
+// StatutoryRate is the annual interest rate in percents applicable from the date.
+type StatutoryRate struct {
+	From time.Time
+	Rate Number
+}
+
+// StatutoryRates stores statutory interest rates. Rates of each type are sorted by date.
+var StatutoryRates = map[InterestType][]StatutoryRate{
+	// Odsetki ustawowe za opóźnienie: NBP reference rate + 5.5 pp.
+	InterestTypeDelay: {
+		statutoryRate(2016, 1, 1, 7, 0),
+		statutoryRate(2020, 3, 18, 6, 50),
+		statutoryRate(2020, 4, 9, 6, 0),
+		statutoryRate(2020, 5, 29, 5, 60),
+		statutoryRate(2021, 10, 7, 6, 0),
+		statutoryRate(2021, 11, 4, 6, 75),
+		statutoryRate(2021, 12, 9, 7, 25),
+		statutoryRate(2022, 1, 5, 7, 75),
+		statutoryRate(2022, 2, 9, 8, 25),
+		statutoryRate(2022, 3, 9, 9, 0),
+		statutoryRate(2022, 4, 7, 10, 0),
+		statutoryRate(2022, 5, 6, 10, 75),
+		statutoryRate(2022, 6, 9, 11, 50),
+		statutoryRate(2022, 7, 8, 12, 0),
+		statutoryRate(2022, 9, 8, 12, 25),
+		statutoryRate(2023, 9, 7, 11, 50),
+		statutoryRate(2023, 10, 5, 11, 25),
+		statutoryRate(2025, 5, 8, 10, 75),
+		statutoryRate(2025, 7, 3, 10, 50),
+		statutoryRate(2025, 9, 4, 10, 25),
+		statutoryRate(2025, 10, 9, 10, 0),
+		statutoryRate(2025, 11, 6, 9, 75),
+		statutoryRate(2025, 12, 4, 9, 50),
+	},
+	// Odsetki ustawowe za opóźnienie w transakcjach handlowych: NBP reference rate as of the first day of the
+	// half-year + 8 pp (10 pp since 2020).
+	InterestTypeCommercial: {
+		statutoryRate(2016, 1, 1, 9, 50),
+		statutoryRate(2020, 1, 1, 11, 50),
+		statutoryRate(2020, 7, 1, 10, 10),
+		statutoryRate(2022, 1, 1, 11, 75),
+		statutoryRate(2022, 7, 1, 16, 0),
+		statutoryRate(2023, 1, 1, 16, 75),
+		statutoryRate(2024, 1, 1, 15, 75),
+		statutoryRate(2025, 7, 1, 15, 25),
+		statutoryRate(2026, 1, 1, 14, 0),
+	},
+}
+
+// StatutoryRatesValidUntil stores the last day on which the latest statutory rate of each type is known to apply.
+// It must be moved forward together with the rates.
+var StatutoryRatesValidUntil = map[InterestType]time.Time{
+	InterestTypeDelay:      time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+	InterestTypeCommercial: time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+}
+
+// StatutoryInterest returns statutory interest on the amount paid after the due date. Interest is charged from the day
+// following the due date until the payment date, at the rates applicable on each day. Error is returned if the period
+// is not covered by the known rates.
+func StatutoryInterest(interestType InterestType, amount Denom, dueDate, paymentDate time.Time) (Denom, error) {
+	rates, exists := StatutoryRates[interestType]
+	if !exists {
+		panic("invalid interest type")
+	}
+
+	interest := NewDenom(amount.Currency)
+	from := civilDate(dueDate).AddDate(0, 0, 1)
+	to := civilDate(paymentDate).AddDate(0, 0, 1)
+	if !from.Before(to) {
+		return interest, nil
+	}
+	if from.Before(rates[0].From) {
+		return Denom{}, errors.Errorf("no statutory interest rate before %s, interest is charged from %s",
+			rates[0].From.Format(time.DateOnly), from.Format(time.DateOnly))
+	}
+	if validUntil := StatutoryRatesValidUntil[interestType]; civilDate(paymentDate).After(validUntil) {
+		return Denom{}, errors.Errorf("statutory interest rates are known until %s, interest is charged until %s",
+			validUntil.Format(time.DateOnly), civilDate(paymentDate).Format(time.DateOnly))
+	}
+
+	for i, r := range rates {
+		end := to
+		if i < len(rates)-1 && rates[i+1].From.Before(end) {
+			end = rates[i+1].From
+		}
+		start := from
+		if r.From.After(start) {
+			start = r.From
+		}
+		if !start.Before(end) {
+			continue
+		}
+		interest = interest.Add(amount.Interest(r.Rate, DaysBetween(start, end)))
+	}
+	return interest, nil
+}
+
+// DaysBetween returns the number of calendar days from one date to the other.
+func DaysBetween(from, to time.Time) uint64 {
+	days := math.Round(civilDate(to).Sub(civilDate(from)).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return uint64(days)
+}
+
+func statutoryRate(year int, month time.Month, day int, i, d uint64) StatutoryRate {
+	return StatutoryRate{
+		From: time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		Rate: NewPercent(i, d),
+	}
+}
+
+// civilDate returns the calendar date in UTC, so dates defined in different locations may be compared.
+func civilDate(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestStatutoryInterest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		interestType InterestType
+		amount       string
+		dueDate      time.Time
+		paymentDate  time.Time
+		expected     string
+	}{
+		{
+			name:         "paid on due date",
+			interestType: InterestTypeDelay,
+			amount:       "1000.00",
+			dueDate:      day(2025, time.November, 30),
+			paymentDate:  day(2025, time.November, 30),
+			expected:     "0.00",
+		},
+		{
+			name:         "rate changed during the delay",
+			interestType: InterestTypeDelay,
+			amount:       "1000.00",
+			dueDate:      day(2025, time.November, 30),
+			paymentDate:  day(2025, time.December, 10),
+			expected:     "2.62",
+		},
+		{
+			name:         "commercial transaction",
+			interestType: InterestTypeCommercial,
+			amount:       "10000.00",
+			dueDate:      day(2025, time.December, 31),
+			paymentDate:  day(2026, time.January, 31),
+			expected:     "118.90",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			interest, err := StatutoryInterest(tt.interestType, denom(tt.amount), tt.dueDate, tt.paymentDate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertDenoms(t, []string{tt.expected}, []Denom{interest})
+		})
+	}
+}
+
+func TestStatutoryInterestUnknownRates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		interestType InterestType
+		dueDate      time.Time
+		paymentDate  time.Time
+	}{
+		{
+			name:         "before the first rate",
+			interestType: InterestTypeDelay,
+			dueDate:      day(2015, time.December, 1),
+			paymentDate:  day(2016, time.January, 10),
+		},
+		{
+			name:         "after the known delay rates",
+			interestType: InterestTypeDelay,
+			dueDate:      day(2025, time.December, 1),
+			paymentDate:  StatutoryRatesValidUntil[InterestTypeDelay].AddDate(0, 0, 1),
+		},
+		{
+			name:         "after the known commercial rates",
+			interestType: InterestTypeCommercial,
+			dueDate:      day(2026, time.March, 1),
+			paymentDate:  StatutoryRatesValidUntil[InterestTypeCommercial].AddDate(0, 0, 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := StatutoryInterest(tt.interestType, denom("100.00"), tt.dueDate, tt.paymentDate); err == nil {
+				t.Fatal("error expected")
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// Contract defines the civil-law contract settled with the employee.
+type Contract struct {
+	Date             time.Time
+	Document         types.Document
+	Employee         types.Employee
+	Type             types.ContractType
+	Amount           types.Denom
+	Insurance        types.Insurance
+	Parameters       types.PayrollParameters
+	CostTaxType      types.CostTaxType
+	CostCategoryType types.CostCategoryType
+	Payments         []types.Payment
+	Notes            string
+	Project          types.Project
+}
+
+// GetDate returns date of the contract bill.
+func (c *Contract) GetDate() time.Time {
+	return c.Date
+}
+
+// GetDocument returns document.
+func (c *Contract) GetDocument() types.Document {
+	return c.Document
+}
+
+// GetContractor returns contractor.
+func (c *Contract) GetContractor() types.Contractor {
+	return c.Employee.Contractor()
+}
+
+// GetNotes returns notes.
+func (c *Contract) GetNotes() string {
+	return c.Notes
+}
+
+// GetProject returns project.
+func (c *Contract) GetProject() types.Project {
+	return c.Project
+}
+
+// GetPayroll returns gross-to-net computation of the contract. Tax advance is withheld on the first payment.
+func (c *Contract) GetPayroll() types.Payroll {
+	payroll := types.ComputePayroll(c.Type, c.Amount, c.Insurance, c.Parameters)
+	payroll.Date = c.Date
+	payroll.Document = c.Document
+	payroll.Employee = c.Employee
+	for _, payment := range c.Payments {
+		if payroll.WithholdingDate.IsZero() || payment.Date.Before(payroll.WithholdingDate) {
+			payroll.WithholdingDate = payment.Date
+		}
+	}
+	return payroll
+}
+
+// BankRecords returns bank records for the contract.
+func (c *Contract) BankRecords() []*types.BankRecord {
+	records := []*types.BankRecord{}
+	for _, payment := range c.Payments {
+		records = append(records, &types.BankRecord{
+			Date:           payment.Date,
+			Index:          payment.Index,
+			Document:       payment.DocumentID,
+			PaidDocument:   c.Document,
+			Contractor:     c.Employee.Contractor(),
+			OriginalAmount: payment.Amount.Neg(),
+		})
+	}
+	return records
+}
+
+// BookRecords returns book records for the contract.
+func (c *Contract) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	if period.End.Before(c.Date) {
+		return nil
+	}
+
+	payroll := c.GetPayroll()
+	cost := payroll.Cost()
+
+	coa.AddEntry(c,
+		types.NewEntryRecord(
+			costTaxTypeToPayrollAccountID(c.CostTaxType),
+			types.DebitBalance(cost),
+		),
+		types.NewEntryRecord(
+			types.NewAccountID(costCategoryTypeToAccountPart(c.CostCategoryType)),
+			types.DebitBalance(cost),
+		),
+		types.NewEntryRecord(
+			types.NewAccountID(accounts.NiewydatkowanyDochod),
+			types.DebitBalance(cost),
+		),
+		types.NewEntryRecord(
+			payrollPayeeToAccountID(types.PayrollPayeeEmployee),
+			types.CreditBalance(payroll.Net),
+		),
+		types.NewEntryRecord(
+			payrollPayeeToAccountID(types.PayrollPayeeTaxOffice),
+			types.CreditBalance(payroll.TaxAdvance),
+		),
+		types.NewEntryRecord(
+			payrollPayeeToAccountID(types.PayrollPayeeSocialInsurance),
+			types.CreditBalance(payroll.SocialInsuranceDue()),
+		),
+	)
+
+	for _, br := range bankRecords {
+		records := []types.EntryRecord{
+			types.NewEntryRecord(
+				payrollPayeeToAccountID(types.PayrollPayeeEmployee),
+				types.DebitBalance(br.BaseAmount.Neg()),
+			),
+		}
+		if mode, ok := coa.BalancedMode(); ok {
+			records = append(records, types.NewEntryRecord(mode.Settlements, types.CreditBalance(br.BaseAmount.Neg())))
+		}
+		coa.AddEntry(types.NewSettlement(c, br), records...)
+	}
+
+	return nil
+}
+
+func costTaxTypeToPayrollAccountID(costTaxType types.CostTaxType) types.AccountID {
+	switch costTaxType {
+	case types.CostTaxTypeTaxable:
+		return types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Wynagrodzenia)
+	case types.CostTaxTypeNonTaxable:
+		return types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Niepodatkowe, accounts.Wynagrodzenia)
+	default:
+		panic("invalid cost tax type")
+	}
+}
+
+func payrollPayeeToAccountID(payee types.PayrollPayee) types.AccountID {
+	switch payee {
+	case types.PayrollPayeeEmployee:
+		return types.NewAccountID(accounts.Rozrachunki, accounts.Wynagrodzenia)
+	case types.PayrollPayeeTaxOffice:
+		return types.NewAccountID(accounts.Rozrachunki, accounts.UrzadSkarbowy)
+	case types.PayrollPayeeSocialInsurance:
+		return types.NewAccountID(accounts.Rozrachunki, accounts.ZUS)
+	default:
+		panic("invalid payroll payee")
+	}
+}
@@ -2,14 +2,21 @@ package operations
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/samber/lo"
+
 	"github.com/outofforest/uepik/v2/accounts"
 	"github.com/outofforest/uepik/v2/report/documents"
 	"github.com/outofforest/uepik/v2/types"
 )
 
+type projectSource interface {
+	GetProject() types.Project
+}
+
 // CurrencyDiff defines the currency diff.
 type CurrencyDiff struct {
 	Contractor types.Contractor
@@ -30,64 +37,98 @@ func (cd *CurrencyDiff) BookRecords(
 	docs := []types.ReportDocument{}
 	for _, month := range period.Months() {
 		cdDate := month.AddDate(0, 1, 0).Add(-time.Nanosecond)
-		cdID := fmt.Sprintf("RK/%d/%d/1", cdDate.Year(), cdDate.Month())
-		source := &CurrencyDiffSource{
-			Document: types.Document{
-				ID:        types.DocumentID(cdID),
-				Date:      cdDate,
-				SheetName: strings.ReplaceAll(cdID, "/", "."),
-			},
-			Contractor: cd.Contractor,
+
+		// Differences are settled by separate document for each project, the one for differences not related to any
+		// project goes first.
+		projectEntries := map[types.Project][]*types.Entry{}
+		for _, e := range coa.EntriesMonth(types.NewAccountID(accounts.RozniceKursowe), cdDate) {
+			project := types.Project("")
+			if ps, ok := e.Data.(projectSource); ok {
+				project = ps.GetProject()
+			}
+			projectEntries[project] = append(projectEntries[project], e)
+		}
+		projects := lo.Keys(projectEntries)
+		if _, exists := projectEntries[""]; !exists {
+			projects = append(projects, "")
 		}
+		sort.Slice(projects, func(i, j int) bool {
+			return projects[i] < projects[j]
+		})
 
-		debit := coa.DebitMonth(types.NewAccountID(accounts.RozniceKursowe), cdDate)
-		credit := coa.CreditMonth(types.NewAccountID(accounts.RozniceKursowe), cdDate)
-
-		coa.AddEntry(
-			source,
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Finansowe,
-					accounts.UjemneRozniceKursowe),
-				types.DebitBalance(debit),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.NiewydatkowanyDochod),
-				types.DebitBalance(debit),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.Nieodplatna),
-				types.DebitBalance(coa.DebitMonth(types.NewAccountID(accounts.RozniceKursowe, accounts.Nieodplatna),
-					cdDate)),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.Odplatna),
-				types.DebitBalance(coa.DebitMonth(types.NewAccountID(accounts.RozniceKursowe, accounts.Odplatna),
-					cdDate)),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe,
-					accounts.DodatnieRozniceKursowe),
-				types.CreditBalance(credit),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.NiewydatkowanyDochod),
-				types.CreditBalance(credit),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.Nieodplatna),
-				types.CreditBalance(coa.CreditMonth(types.NewAccountID(accounts.RozniceKursowe, accounts.Nieodplatna),
-					cdDate)),
-			),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.Odplatna),
-				types.CreditBalance(coa.CreditMonth(types.NewAccountID(accounts.RozniceKursowe, accounts.Odplatna),
-					cdDate)),
-			),
-		)
-
-		entries := coa.EntriesMonth(types.NewAccountID(accounts.RozniceKursowe), cdDate)
-		if len(entries) > 0 {
-			docs = append(docs, documents.GenerateCurrencyDiffDocument(source.Document, cd.Contractor, entries))
+		for i, project := range projects {
+			cdID := fmt.Sprintf("RK/%d/%d/%d", cdDate.Year(), cdDate.Month(), i+1)
+			source := &CurrencyDiffSource{
+				Document: types.Document{
+					ID:        types.DocumentID(cdID),
+					Date:      cdDate,
+					SheetName: strings.ReplaceAll(cdID, "/", "."),
+				},
+				Contractor: cd.Contractor,
+				Project:    project,
+			}
+			entries := projectEntries[project]
+			amount := func(parts ...types.AccountIDPart) types.AccountBalance {
+				accountID := types.NewAccountID(append([]types.AccountIDPart{accounts.RozniceKursowe}, parts...)...)
+				sum := types.DebitBalance(types.BaseZero)
+				for _, e := range entries {
+					sum = sum.Add(coa.Amount(accountID, e.ID))
+				}
+				return sum
+			}
+
+			total := amount()
+			nonProfit := amount(accounts.Nieodplatna)
+			profit := amount(accounts.Odplatna)
+
+			records := []types.EntryRecord{
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Finansowe,
+						accounts.UjemneRozniceKursowe),
+					types.DebitBalance(total.Debit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.NiewydatkowanyDochod),
+					types.DebitBalance(total.Debit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.Nieodplatna),
+					types.DebitBalance(nonProfit.Debit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.Odplatna),
+					types.DebitBalance(profit.Debit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe,
+						accounts.DodatnieRozniceKursowe),
+					types.CreditBalance(total.Credit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.NiewydatkowanyDochod),
+					types.CreditBalance(total.Credit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.Nieodplatna),
+					types.CreditBalance(nonProfit.Credit),
+				),
+				types.NewEntryRecord(
+					types.NewAccountID(accounts.Odplatna),
+					types.CreditBalance(profit.Credit),
+				),
+			}
+			if mode, ok := coa.BalancedMode(); ok {
+				// Losses decrease receivables and gains decrease liabilities.
+				records = append(records,
+					types.NewEntryRecord(mode.Settlements, types.CreditBalance(total.Debit)),
+					types.NewEntryRecord(mode.Settlements, types.DebitBalance(total.Credit)),
+				)
+			}
+			coa.AddEntry(source, records...)
+
+			if len(entries) > 0 {
+				docs = append(docs, documents.GenerateCurrencyDiffDocument(source.Document, cd.Contractor, entries))
+			}
 		}
 	}
 	return docs
@@ -97,6 +138,7 @@ func (cd *CurrencyDiff) BookRecords(
 type CurrencyDiffSource struct {
 	Document   types.Document
 	Contractor types.Contractor
+	Project    types.Project
 }
 
 // GetDate returns date of currency diff.
@@ -118,3 +160,8 @@ func (cds *CurrencyDiffSource) GetContractor() types.Contractor {
 func (cds *CurrencyDiffSource) GetNotes() string {
 	return "Różnice kursowe"
 }
+
+// GetProject returns project.
+func (cds *CurrencyDiffSource) GetProject() types.Project {
+	return cds.Project
+}
@@ -11,6 +11,7 @@ import (
 type Donation struct {
 	Contractor types.Contractor
 	Payment    types.Payment
+	Project    types.Project
 }
 
 // GetDate returns date of donation.
@@ -36,6 +37,16 @@ func (d *Donation) GetNotes() string {
 	return "Darowizna na cele statutowe"
 }
 
+// GetProject returns project.
+func (d *Donation) GetProject() types.Project {
+	return d.Project
+}
+
+// DonatedAmount returns amount of the donation.
+func (d *Donation) DonatedAmount() types.Denom {
+	return d.Payment.Amount
+}
+
 // BankRecords returns bank records for the donation.
 func (d *Donation) BankRecords() []*types.BankRecord {
 	return []*types.BankRecord{{
@@ -61,7 +72,7 @@ func (d *Donation) BookRecords(
 
 	incomeBase, _ := rates.ToBase(d.Payment.Amount, types.PreviousDay(d.Payment.Date))
 
-	coa.AddEntry(d,
+	records := []types.EntryRecord{
 		types.NewEntryRecord(
 			types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne, accounts.Nieodplatna),
 			types.CreditBalance(incomeBase),
@@ -74,7 +85,11 @@ func (d *Donation) BookRecords(
 			types.NewAccountID(accounts.NiewydatkowanyDochod),
 			types.CreditBalance(incomeBase),
 		),
-	)
+	}
+	if mode, ok := coa.BalancedMode(); ok {
+		records = append(records, types.NewEntryRecord(mode.Settlements, types.DebitBalance(incomeBase)))
+	}
+	coa.AddEntry(d, records...)
 
 	return nil
 }
@@ -0,0 +1,30 @@
+package operations
+
+import (
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// EquipmentDisposal defines the disposal of the equipment item.
+type EquipmentDisposal struct {
+	Disposal types.EquipmentDisposal
+}
+
+// GetEquipmentDisposal returns the disposal of the equipment item.
+func (ed *EquipmentDisposal) GetEquipmentDisposal() types.EquipmentDisposal {
+	return ed.Disposal
+}
+
+// BankRecords returns bank records for the equipment disposal.
+func (ed *EquipmentDisposal) BankRecords() []*types.BankRecord {
+	return nil
+}
+
+// BookRecords returns book records for the equipment disposal.
+func (ed *EquipmentDisposal) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	return nil
+}
@@ -0,0 +1,259 @@
+package operations
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// FixedAsset defines the purchase of fixed asset, which cost is recognized by depreciation. Opening fixed assets
+// were purchased before the fiscal year, so only their depreciation is booked.
+type FixedAsset struct {
+	Date             time.Time
+	Document         types.Document
+	Contractor       types.Contractor
+	Amount           types.Denom
+	Payments         []types.Payment
+	CostTaxType      types.CostTaxType
+	CostCategoryType types.CostCategoryType
+	Asset            types.FixedAsset
+	Notes            string
+	Project          types.Project
+	Opening          bool
+}
+
+// NewOpeningFixedAsset creates the operation depreciating fixed asset purchased before the fiscal year.
+func NewOpeningFixedAsset(asset types.OpeningFixedAsset) *FixedAsset {
+	return &FixedAsset{
+		Date:             asset.Asset.Date,
+		Document:         asset.Asset.Document,
+		Amount:           asset.Asset.InitialValue,
+		CostTaxType:      asset.CostTaxType,
+		CostCategoryType: asset.CostCategoryType,
+		Asset:            asset.Asset,
+		Project:          asset.Project,
+		Opening:          true,
+	}
+}
+
+// OpeningFixedAsset returns the fixed asset to be carried forward to the next fiscal year.
+func (fa *FixedAsset) OpeningFixedAsset(rates types.CurrencyRates) types.OpeningFixedAsset {
+	return types.OpeningFixedAsset{
+		Asset:            fa.GetFixedAsset(rates),
+		CostTaxType:      fa.CostTaxType,
+		CostCategoryType: fa.CostCategoryType,
+		Project:          fa.Project,
+	}
+}
+
+// GetDate returns date of purchase.
+func (fa *FixedAsset) GetDate() time.Time {
+	return fa.Date
+}
+
+// GetDocument returns document.
+func (fa *FixedAsset) GetDocument() types.Document {
+	return fa.Document
+}
+
+// GetContractor returns contractor.
+func (fa *FixedAsset) GetContractor() types.Contractor {
+	return fa.Contractor
+}
+
+// GetNotes returns notes.
+func (fa *FixedAsset) GetNotes() string {
+	return fa.Notes
+}
+
+// GetProject returns project.
+func (fa *FixedAsset) GetProject() types.Project {
+	return fa.Project
+}
+
+// GetCorrectedDocument returns the document corrected by the credit note. Credit notes of fixed assets are not
+// supported.
+func (fa *FixedAsset) GetCorrectedDocument() types.DocumentID {
+	return ""
+}
+
+// SettlementDues returns payables of the fixed asset purchase.
+func (fa *FixedAsset) SettlementDues() []types.Due {
+	if fa.Opening {
+		return nil
+	}
+	return []types.Due{{
+		Date:   fa.Date,
+		Amount: fa.Amount.Neg(),
+	}}
+}
+
+// GetFixedAsset returns fixed asset definition.
+func (fa *FixedAsset) GetFixedAsset(rates types.CurrencyRates) types.FixedAsset {
+	asset := fa.Asset
+	asset.Date = fa.Date
+	asset.Document = fa.Document
+	asset.InitialValue, _ = rates.ToBase(fa.Amount, types.PreviousDay(fa.Date))
+	return asset
+}
+
+// BankRecords returns bank records for the fixed asset.
+func (fa *FixedAsset) BankRecords() []*types.BankRecord {
+	records := []*types.BankRecord{}
+	for _, payment := range fa.Payments {
+		records = append(records, &types.BankRecord{
+			Date:           payment.Date,
+			Index:          payment.Index,
+			Document:       payment.DocumentID,
+			PaidDocument:   fa.Document,
+			Contractor:     fa.Contractor,
+			OriginalAmount: payment.Amount.Neg(),
+		})
+	}
+	return records
+}
+
+// BookRecords returns book records for the fixed asset.
+func (fa *FixedAsset) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	if period.End.Before(fa.Date) {
+		return nil
+	}
+
+	asset := fa.GetFixedAsset(rates)
+	mode, balanced := coa.BalancedMode()
+	if !fa.Opening {
+		fa.bookPurchase(coa, bankRecords, rates, asset)
+	}
+
+	for _, d := range asset.DepreciationPlan() {
+		source := newDepreciationSource(fa, d)
+		if !period.Contains(source.GetDate()) {
+			continue
+		}
+		records := []types.EntryRecord{
+			types.NewEntryRecord(
+				costTaxTypeToDepreciationAccountID(fa.CostTaxType),
+				types.DebitBalance(d.Amount),
+			),
+		}
+		if balanced {
+			records = append(records, types.NewEntryRecord(mode.Depreciation, types.CreditBalance(d.Amount)))
+		}
+		coa.AddEntry(source, records...)
+	}
+
+	return nil
+}
+
+func (fa *FixedAsset) bookPurchase(
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+	asset types.FixedAsset,
+) {
+	_, costRate := rates.ToBase(fa.Amount, types.PreviousDay(fa.Date))
+
+	mode, balanced := coa.BalancedMode()
+
+	records := []types.EntryRecord{
+		types.NewEntryRecord(
+			types.NewAccountID(costCategoryTypeToAccountPart(fa.CostCategoryType)),
+			types.DebitBalance(asset.InitialValue),
+		),
+		types.NewEntryRecord(
+			types.NewAccountID(accounts.NiewydatkowanyDochod),
+			types.DebitBalance(asset.InitialValue),
+		),
+	}
+	if balanced {
+		records = append(records,
+			types.NewEntryRecord(mode.FixedAssets, types.DebitBalance(asset.InitialValue)),
+			types.NewEntryRecord(mode.Settlements, types.CreditBalance(asset.InitialValue)),
+		)
+	}
+	coa.AddEntry(fa, records...)
+
+	for _, br := range bankRecords {
+		if br.Rate.EQ(costRate) {
+			continue
+		}
+
+		paymentOriginal := br.OriginalAmount.Neg()
+		var amount types.AccountBalance
+		if costRate.GT(br.Rate) {
+			amount = types.CreditBalance(paymentOriginal.ToBase(costRate.Sub(br.Rate)))
+		} else {
+			amount = types.DebitBalance(paymentOriginal.ToBase(br.Rate.Sub(costRate)))
+		}
+
+		coa.AddEntry(types.NewCurrencyDiff(fa, costRate, br),
+			types.NewEntryRecord(
+				types.NewAccountID(accounts.RozniceKursowe, costCategoryTypeToAccountPart(fa.CostCategoryType)),
+				amount,
+			),
+		)
+	}
+}
+
+func newDepreciationSource(asset *FixedAsset, depreciation types.Depreciation) *DepreciationSource {
+	date := depreciation.GetDate()
+	return &DepreciationSource{
+		Document: types.Document{
+			ID: types.DocumentID(fmt.Sprintf("AM/%d/%d/%s", date.Year(), date.Month(),
+				asset.Asset.InventoryNumber)),
+			Date: date,
+		},
+		Asset:        asset,
+		Depreciation: depreciation,
+	}
+}
+
+// DepreciationSource is the source of depreciation write-off.
+type DepreciationSource struct {
+	Document     types.Document
+	Asset        *FixedAsset
+	Depreciation types.Depreciation
+}
+
+// GetDate returns date of depreciation write-off.
+func (ds *DepreciationSource) GetDate() time.Time {
+	return ds.Document.Date
+}
+
+// GetDocument returns document.
+func (ds *DepreciationSource) GetDocument() types.Document {
+	return ds.Document
+}
+
+// GetContractor returns contractor.
+func (ds *DepreciationSource) GetContractor() types.Contractor {
+	return types.Contractor{}
+}
+
+// GetNotes returns notes.
+func (ds *DepreciationSource) GetNotes() string {
+	return fmt.Sprintf("Amortyzacja: %s (%s)", ds.Asset.Asset.Name, ds.Asset.Asset.InventoryNumber)
+}
+
+// GetProject returns project.
+func (ds *DepreciationSource) GetProject() types.Project {
+	return ds.Asset.Project
+}
+
+func costTaxTypeToDepreciationAccountID(costTaxType types.CostTaxType) types.AccountID {
+	switch costTaxType {
+	case types.CostTaxTypeTaxable:
+		return types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Podatkowe, accounts.Amortyzacja)
+	case types.CostTaxTypeNonTaxable:
+		return types.NewAccountID(accounts.PiK, accounts.Koszty, accounts.Niepodatkowe, accounts.Amortyzacja)
+	default:
+		panic("invalid cost tax type")
+	}
+}
@@ -0,0 +1,89 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// Grant defines the income coming from the tranche of grant received for statutory activity.
+type Grant struct {
+	Document   types.Document
+	Contractor types.Contractor
+	Payment    types.Payment
+	Notes      string
+	Project    types.Project
+}
+
+// GetDate returns date of grant tranche.
+func (g *Grant) GetDate() time.Time {
+	return g.Payment.Date
+}
+
+// GetDocument returns document.
+func (g *Grant) GetDocument() types.Document {
+	return g.Document
+}
+
+// GetContractor returns contractor.
+func (g *Grant) GetContractor() types.Contractor {
+	return g.Contractor
+}
+
+// GetNotes returns notes.
+func (g *Grant) GetNotes() string {
+	return g.Notes
+}
+
+// GetProject returns project.
+func (g *Grant) GetProject() types.Project {
+	return g.Project
+}
+
+// BankRecords returns bank records for the grant.
+func (g *Grant) BankRecords() []*types.BankRecord {
+	return []*types.BankRecord{{
+		Date:           g.Payment.Date,
+		Index:          g.Payment.Index,
+		Document:       g.Payment.DocumentID,
+		PaidDocument:   g.Document,
+		Contractor:     g.Contractor,
+		OriginalAmount: g.Payment.Amount,
+	}}
+}
+
+// BookRecords returns book records for the grant.
+func (g *Grant) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	if period.End.Before(g.Payment.Date) {
+		return nil
+	}
+
+	incomeBase, _ := rates.ToBase(g.Payment.Amount, types.PreviousDay(g.Payment.Date))
+
+	records := []types.EntryRecord{
+		types.NewEntryRecord(
+			types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne, accounts.Dotacje),
+			types.CreditBalance(incomeBase),
+		),
+		types.NewEntryRecord(
+			types.NewAccountID(accounts.Nieodplatna),
+			types.CreditBalance(incomeBase),
+		),
+		types.NewEntryRecord(
+			types.NewAccountID(accounts.NiewydatkowanyDochod),
+			types.CreditBalance(incomeBase),
+		),
+	}
+	if mode, ok := coa.BalancedMode(); ok {
+		records = append(records, types.NewEntryRecord(mode.Settlements, types.DebitBalance(incomeBase)))
+	}
+	coa.AddEntry(g, records...)
+
+	return nil
+}
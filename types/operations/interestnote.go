@@ -0,0 +1,136 @@
+package operations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/report/documents"
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// InterestNotes books statutory interest on receivables paid after the due date.
+type InterestNotes struct {
+	Type       types.InterestType
+	Operations []types.Operation
+}
+
+// BankRecords returns bank records for interest notes.
+func (in *InterestNotes) BankRecords() []*types.BankRecord {
+	return nil
+}
+
+// BookRecords returns book records for interest notes. One note is issued for each document paid late in the period,
+// on the date of the last late payment. Notes are not issued for documents on which interest can't be calculated
+// because statutory rates are not known yet.
+func (in *InterestNotes) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	notes := map[string]*InterestNoteSource{}
+	unknown := map[string]bool{}
+	for _, r := range documents.LateInterests(in.Type, period.End, in.Operations) {
+		if !r.Paid || !period.Contains(r.PaymentDate) {
+			continue
+		}
+
+		key := r.Contractor.Key() + "/" + string(r.Document.ID) + "/" + string(r.Interest.Currency)
+		if r.RateUnknown {
+			unknown[key] = true
+			continue
+		}
+		if r.Interest.Amount.IsZero() {
+			continue
+		}
+		note, exists := notes[key]
+		if !exists {
+			note = &InterestNoteSource{
+				Contractor: r.Contractor,
+				Settled:    r.Document.ID,
+				Project:    r.Project,
+				Amount:     types.NewDenom(r.Interest.Currency),
+			}
+			notes[key] = note
+		}
+		note.Document.Date = types.MaxDate(note.Document.Date, r.PaymentDate)
+		note.Amount = note.Amount.Add(r.Interest)
+	}
+
+	sorted := make([]*InterestNoteSource, 0, len(notes))
+	for key, note := range notes {
+		if !unknown[key] {
+			sorted = append(sorted, note)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].Document.Date.Equal(sorted[j].Document.Date) {
+			return sorted[i].Document.Date.Before(sorted[j].Document.Date)
+		}
+		if sorted[i].Contractor.Name != sorted[j].Contractor.Name {
+			return strings.Compare(sorted[i].Contractor.Name, sorted[j].Contractor.Name) < 0
+		}
+		return strings.Compare(string(sorted[i].Settled), string(sorted[j].Settled)) < 0
+	})
+
+	for i, note := range sorted {
+		id := fmt.Sprintf("NO/%d/%d", note.Document.Date.Year(), i+1)
+		note.Document.ID = types.DocumentID(id)
+		note.Document.SheetName = strings.ReplaceAll(id, "/", ".")
+
+		amount, _ := rates.ToBaseLatest(note.Amount, types.PreviousDay(note.Document.Date))
+		records := []types.EntryRecord{
+			types.NewEntryRecord(
+				types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe, accounts.Odsetki),
+				types.CreditBalance(amount),
+			),
+			types.NewEntryRecord(
+				types.NewAccountID(accounts.NiewydatkowanyDochod),
+				types.CreditBalance(amount),
+			),
+		}
+		if mode, ok := coa.BalancedMode(); ok {
+			records = append(records, types.NewEntryRecord(mode.Settlements, types.DebitBalance(amount)))
+		}
+		coa.AddEntry(note, records...)
+	}
+
+	return nil
+}
+
+// InterestNoteSource is the source of interest note.
+type InterestNoteSource struct {
+	Document   types.Document
+	Contractor types.Contractor
+	Settled    types.DocumentID
+	Project    types.Project
+	Amount     types.Denom
+}
+
+// GetDate returns date of interest note.
+func (ins *InterestNoteSource) GetDate() time.Time {
+	return ins.Document.Date
+}
+
+// GetDocument returns document.
+func (ins *InterestNoteSource) GetDocument() types.Document {
+	return ins.Document
+}
+
+// GetContractor returns contractor.
+func (ins *InterestNoteSource) GetContractor() types.Contractor {
+	return ins.Contractor
+}
+
+// GetNotes returns notes.
+func (ins *InterestNoteSource) GetNotes() string {
+	return fmt.Sprintf("Odsetki za opóźnienie w zapłacie %s (%s)", ins.Settled, ins.Amount)
+}
+
+// GetProject returns project of the settled document.
+func (ins *InterestNoteSource) GetProject() types.Project {
+	return ins.Project
+}
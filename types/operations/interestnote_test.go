@@ -0,0 +1,98 @@
+package operations_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/outofforest/uepik/v2/accounts"
+	"github.com/outofforest/uepik/v2/report"
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+func pln(amount string) types.Denom {
+	d, err := types.ParseDenom(amount, types.PLN)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func day(year int, month time.Month, d int) time.Time {
+	return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+}
+
+func yearPeriod(year int) types.Period {
+	return types.Period{
+		Start: day(year, time.January, 1),
+		End:   day(year, time.December, 31),
+	}
+}
+
+func newChart(period types.Period) *types.ChartOfAccounts {
+	return types.NewChartOfAccounts(period, report.DefaultChartOfAccounts()...)
+}
+
+var contractor = types.Contractor{Name: "Żaglownia", TaxID: "5861234567"}
+
+func lateSell(id types.DocumentID, dueDate, paymentDate time.Time, amount string) *operations.Sell {
+	return &operations.Sell{
+		Date:       dueDate.AddDate(0, 0, -14),
+		Document:   types.Document{ID: id, Date: dueDate.AddDate(0, 0, -14)},
+		Contractor: contractor,
+		Dues:       []types.Due{{Date: dueDate, Amount: pln(amount)}},
+		Payments:   []types.Payment{{Date: paymentDate, Amount: pln(amount)}},
+	}
+}
+
+func TestInterestNotes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		period     types.Period
+		operations []types.Operation
+		expected   []string
+	}{
+		{
+			name:   "paid late",
+			period: yearPeriod(2025),
+			operations: []types.Operation{
+				lateSell("FS/1", day(2025, time.January, 31), day(2025, time.March, 2), "1000.00"),
+				lateSell("FS/2", day(2025, time.April, 30), day(2025, time.April, 30), "1000.00"),
+			},
+			expected: []string{"9.25"},
+		},
+		{
+			name:   "paid after the end of the rate table",
+			period: yearPeriod(2026),
+			operations: []types.Operation{
+				lateSell("FS/1", day(2025, time.December, 1), day(2026, time.January, 15), "1000.00"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			coa := newChart(tt.period)
+			notes := &operations.InterestNotes{
+				Type:       types.InterestTypeDelay,
+				Operations: tt.operations,
+			}
+			notes.BookRecords(tt.period, coa, nil, nil)
+
+			entries := coa.Entries(types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Finansowe,
+				accounts.Odsetki))
+			if len(entries) != len(tt.expected) {
+				t.Fatalf("expected %d notes, got %d", len(tt.expected), len(entries))
+			}
+			for i, e := range entries {
+				if amount := e.Amount.Credit; amount.NEQ(pln(tt.expected[i])) {
+					t.Errorf("note %d: expected %s, got %s", i, tt.expected[i], amount.Amount)
+				}
+			}
+		})
+	}
+}
@@ -55,5 +55,25 @@ func (p *Payment) BookRecords(
 	bankRecords []*types.BankRecord,
 	rates types.CurrencyRates,
 ) []types.ReportDocument {
+	mode, ok := coa.BalancedMode()
+	if !ok {
+		return nil
+	}
+
+	// Payments not related to any document change the statutory fund.
+	for _, br := range bankRecords {
+		if br.BaseAmount.GT(types.BaseZero) {
+			coa.AddEntry(p,
+				types.NewEntryRecord(mode.Settlements, types.DebitBalance(br.BaseAmount)),
+				types.NewEntryRecord(mode.Fund, types.CreditBalance(br.BaseAmount)),
+			)
+			continue
+		}
+		coa.AddEntry(p,
+			types.NewEntryRecord(mode.Settlements, types.CreditBalance(br.BaseAmount.Neg())),
+			types.NewEntryRecord(mode.Fund, types.DebitBalance(br.BaseAmount.Neg())),
+		)
+	}
+
 	return nil
 }
@@ -0,0 +1,76 @@
+package operations
+
+import (
+	"time"
+
+	"github.com/outofforest/uepik/v2/types"
+)
+
+// PayrollPayment defines the payment of payroll liabilities to the tax office or social insurance institution.
+type PayrollPayment struct {
+	Date       time.Time
+	Document   types.Document
+	Contractor types.Contractor
+	Payee      types.PayrollPayee
+	Payments   []types.Payment
+	Notes      string
+}
+
+// GetDate returns date of payment.
+func (pp *PayrollPayment) GetDate() time.Time {
+	return pp.Date
+}
+
+// GetDocument returns document.
+func (pp *PayrollPayment) GetDocument() types.Document {
+	return pp.Document
+}
+
+// GetContractor returns contractor.
+func (pp *PayrollPayment) GetContractor() types.Contractor {
+	return pp.Contractor
+}
+
+// GetNotes returns notes.
+func (pp *PayrollPayment) GetNotes() string {
+	return pp.Notes
+}
+
+// BankRecords returns bank records for the payroll payment.
+func (pp *PayrollPayment) BankRecords() []*types.BankRecord {
+	records := []*types.BankRecord{}
+	for _, payment := range pp.Payments {
+		records = append(records, &types.BankRecord{
+			Date:           payment.Date,
+			Index:          payment.Index,
+			Document:       payment.DocumentID,
+			PaidDocument:   pp.Document,
+			Contractor:     pp.Contractor,
+			OriginalAmount: payment.Amount.Neg(),
+		})
+	}
+	return records
+}
+
+// BookRecords returns book records for the payroll payment.
+func (pp *PayrollPayment) BookRecords(
+	period types.Period,
+	coa *types.ChartOfAccounts,
+	bankRecords []*types.BankRecord,
+	rates types.CurrencyRates,
+) []types.ReportDocument {
+	for _, br := range bankRecords {
+		records := []types.EntryRecord{
+			types.NewEntryRecord(
+				payrollPayeeToAccountID(pp.Payee),
+				types.DebitBalance(br.BaseAmount.Neg()),
+			),
+		}
+		if mode, ok := coa.BalancedMode(); ok {
+			records = append(records, types.NewEntryRecord(mode.Settlements, types.CreditBalance(br.BaseAmount.Neg())))
+		}
+		coa.AddEntry(types.NewSettlement(pp, br), records...)
+	}
+
+	return nil
+}
@@ -9,14 +9,22 @@ import (
 
 // Purchase defines the cost of purchased goods or service.
 type Purchase struct {
-	Date             time.Time
-	Document         types.Document
-	Contractor       types.Contractor
-	Amount           types.Denom
-	Payments         []types.Payment
-	CostTaxType      types.CostTaxType
-	CostCategoryType types.CostCategoryType
-	Notes            string
+	Date        time.Time
+	Document    types.Document
+	Contractor  types.Contractor
+	Amount      types.Denom
+	Payments    []types.Payment
+	Allocations []types.CostAllocation
+	VATLines    []types.PurchaseVATLine
+	Equipment   []types.EquipmentItem
+	Notes       string
+	Project     types.Project
+
+	// DueDate is the payment deadline. Date of purchase is used if it is not set.
+	DueDate time.Time
+
+	// CorrectedDocument is set if the purchase is the credit note of another one.
+	CorrectedDocument types.DocumentID
 }
 
 // GetDate returns date of purchase.
@@ -39,6 +47,49 @@ func (p *Purchase) GetNotes() string {
 	return p.Notes
 }
 
+// GetProject returns project.
+func (p *Purchase) GetProject() types.Project {
+	return p.Project
+}
+
+// GetCorrectedDocument returns the document corrected by the credit note.
+func (p *Purchase) GetCorrectedDocument() types.DocumentID {
+	return p.CorrectedDocument
+}
+
+// SettlementDues returns payables of the purchase.
+func (p *Purchase) SettlementDues() []types.Due {
+	return []types.Due{{
+		Date:   p.dueDate(),
+		Amount: p.Amount.Neg(),
+	}}
+}
+
+// GetDues returns dues.
+func (p *Purchase) GetDues() []types.Due {
+	return []types.Due{{
+		Date:   p.dueDate(),
+		Amount: p.Amount,
+	}}
+}
+
+// GetPayments returns payments.
+func (p *Purchase) GetPayments() []types.Payment {
+	return p.Payments
+}
+
+func (p *Purchase) dueDate() time.Time {
+	if p.DueDate.IsZero() {
+		return p.Date
+	}
+	return p.DueDate
+}
+
+// GetEquipment returns equipment items bought.
+func (p *Purchase) GetEquipment() []types.EquipmentItem {
+	return p.Equipment
+}
+
 // BankRecords returns bank records for the purchase.
 func (p *Purchase) BankRecords() []*types.BankRecord {
 	records := []*types.BankRecord{}
@@ -66,22 +117,44 @@ func (p *Purchase) BookRecords(
 		return nil
 	}
 
-	costBase, costRate := rates.ToBase(p.Amount, types.PreviousDay(p.Date))
+	grossBase, costRate := rates.ToBase(p.Amount, types.PreviousDay(p.Date))
+	weights := p.allocationWeights()
 
-	coa.AddEntry(p,
-		types.NewEntryRecord(
-			costTaxTypeToAccountID(p.CostTaxType),
-			types.DebitBalance(costBase),
-		),
+	vatBase := types.BaseZero
+	for _, a := range p.InputVAT(rates) {
+		if a.Deductible {
+			vatBase = vatBase.Add(a.VAT)
+		}
+	}
+	costBase := grossBase.Sub(vatBase)
+
+	records := make([]types.EntryRecord, 0, 2*len(p.Allocations)+3)
+	for i, costPart := range costBase.Allocate(weights...) {
+		records = append(records,
+			types.NewEntryRecord(
+				costTaxTypeToAccountID(p.Allocations[i].CostTaxType),
+				types.DebitBalance(costPart),
+			),
+			types.NewEntryRecord(
+				types.NewAccountID(costCategoryTypeToAccountPart(p.Allocations[i].CostCategoryType)),
+				types.DebitBalance(costPart),
+			),
+		)
+	}
+	records = append(records,
 		types.NewEntryRecord(
-			types.NewAccountID(costCategoryTypeToAccountPart(p.CostCategoryType)),
+			types.NewAccountID(accounts.NiewydatkowanyDochod),
 			types.DebitBalance(costBase),
 		),
 		types.NewEntryRecord(
-			types.NewAccountID(accounts.NiewydatkowanyDochod),
-			types.DebitBalance(costBase),
+			types.NewAccountID(accounts.RozliczenieVAT, accounts.Naliczony),
+			types.DebitBalance(vatBase),
 		),
 	)
+	if mode, ok := coa.BalancedMode(); ok {
+		records = append(records, types.NewEntryRecord(mode.Settlements, types.CreditBalance(grossBase)))
+	}
+	coa.AddEntry(p, records...)
 
 	for _, br := range bankRecords {
 		if br.Rate.EQ(costRate) {
@@ -89,24 +162,57 @@ func (p *Purchase) BookRecords(
 		}
 
 		paymentOriginal := br.OriginalAmount.Neg()
-		var amount types.AccountBalance
+		var diff types.Denom
+		var balanceFn func(amount types.Denom) types.AccountBalance
 		if costRate.GT(br.Rate) {
-			amount = types.CreditBalance(paymentOriginal.ToBase(costRate.Sub(br.Rate)))
+			diff = paymentOriginal.ToBase(costRate.Sub(br.Rate))
+			balanceFn = types.CreditBalance
 		} else {
-			amount = types.DebitBalance(paymentOriginal.ToBase(br.Rate.Sub(costRate)))
+			diff = paymentOriginal.ToBase(br.Rate.Sub(costRate))
+			balanceFn = types.DebitBalance
 		}
 
-		coa.AddEntry(types.NewCurrencyDiff(p, costRate, br),
-			types.NewEntryRecord(
-				types.NewAccountID(accounts.RozniceKursowe, costCategoryTypeToAccountPart(p.CostCategoryType)),
-				amount,
-			),
-		)
+		records := make([]types.EntryRecord, 0, len(p.Allocations))
+		for i, diffPart := range diff.Allocate(weights...) {
+			records = append(records, types.NewEntryRecord(
+				types.NewAccountID(accounts.RozniceKursowe,
+					costCategoryTypeToAccountPart(p.Allocations[i].CostCategoryType)),
+				balanceFn(diffPart),
+			))
+		}
+		coa.AddEntry(types.NewCurrencyDiff(p, costRate, br), records...)
 	}
 
 	return nil
 }
 
+// InputVAT returns net amounts and input VAT of the purchase in base currency, grouped by VAT rate and
+// deductibility.
+func (p *Purchase) InputVAT(rates types.CurrencyRates) []types.VATAmount {
+	_, costRate := rates.ToBase(p.Amount, types.PreviousDay(p.Date))
+	amounts := []types.VATAmount{}
+	for _, line := range p.VATLines {
+		vat := line.Rate.VAT(line.Gross).ToBase(costRate)
+		amounts = types.AddVATAmount(amounts, types.VATAmount{
+			Rate:       line.Rate,
+			Net:        line.Gross.ToBase(costRate).Sub(vat),
+			VAT:        vat,
+			Deductible: line.Deductible,
+		})
+	}
+	return amounts
+}
+
+// allocationWeights returns amounts of allocated cost parts used to split amounts in base currency.
+func (p *Purchase) allocationWeights() []types.Number {
+	parts := types.AllocateCost(p.Amount, p.Allocations)
+	weights := make([]types.Number, 0, len(parts))
+	for _, part := range parts {
+		weights = append(weights, part.Amount.Abs())
+	}
+	return weights
+}
+
 func costTaxTypeToAccountID(costTaxType types.CostTaxType) types.AccountID {
 	switch costTaxType {
 	case types.CostTaxTypeTaxable:
@@ -14,8 +14,12 @@ type Sell struct {
 	Contractor types.Contractor
 	Dues       []types.Due
 	Payments   []types.Payment
-	Type       types.SellType
+	Lines      []types.InvoiceLine
 	Notes      string
+	Project    types.Project
+
+	// CorrectedDocument is set if the sell is the credit note of another one.
+	CorrectedDocument types.DocumentID
 }
 
 // GetDate returns date of sell.
@@ -38,16 +42,36 @@ func (s *Sell) GetNotes() string {
 	return s.Notes
 }
 
+// GetProject returns project.
+func (s *Sell) GetProject() types.Project {
+	return s.Project
+}
+
 // GetDues returns dues.
 func (s *Sell) GetDues() []types.Due {
 	return s.Dues
 }
 
+// GetLines returns invoice lines.
+func (s *Sell) GetLines() []types.InvoiceLine {
+	return s.Lines
+}
+
 // GetPayments returns payments.
 func (s *Sell) GetPayments() []types.Payment {
 	return s.Payments
 }
 
+// GetCorrectedDocument returns the document corrected by the credit note.
+func (s *Sell) GetCorrectedDocument() types.DocumentID {
+	return s.CorrectedDocument
+}
+
+// SettlementDues returns receivables of the sell.
+func (s *Sell) SettlementDues() []types.Due {
+	return s.Dues
+}
+
 // BankRecords returns bank records for the sell.
 func (s *Sell) BankRecords() []*types.BankRecord {
 	records := []*types.BankRecord{}
@@ -74,48 +98,79 @@ func (s *Sell) BookRecords(
 	if len(s.Dues) == 0 {
 		panic("no dues")
 	}
+	if len(s.Lines) == 0 {
+		panic("no invoice lines")
+	}
 
 	if !period.End.Before(s.Date) {
-		amount := s.Dues[0].Amount
-		for _, due := range s.Dues[1:] {
-			amount = amount.Add(due.Amount)
-		}
+		grossParts, vatParts, incomeRate := s.lineAmounts(rates)
+		weights := s.lineWeights()
 
-		incomeBase, incomeRate := rates.ToBase(amount, types.PreviousDay(s.Date))
-
-		coa.AddEntry(s,
-			types.NewEntryRecord(
-				sellTypeToAccountID(s.Type),
-				types.CreditBalance(incomeBase),
-			),
+		incomeBase := types.BaseZero
+		recordedBase := types.BaseZero
+		vatBase := types.BaseZero
+		records := make([]types.EntryRecord, 0, 2*len(s.Lines)+3)
+		for i, grossPart := range grossParts {
+			line := s.Lines[i]
+			incomePart := grossPart.Sub(vatParts[i])
+			incomeBase = incomeBase.Add(incomePart)
+			vatBase = vatBase.Add(vatParts[i])
+			if line.SellType == types.SellTypeRecorded {
+				recordedBase = recordedBase.Add(incomePart)
+			}
+			records = append(records, types.NewEntryRecord(
+				sellTypeToAccountID(line.SellType, line.IncomeType),
+				types.CreditBalance(incomePart),
+			))
+			if line.IncomeType == types.IncomeTypePaid {
+				records = append(records, types.NewEntryRecord(
+					types.NewAccountID(accounts.Odplatna),
+					types.CreditBalance(incomePart),
+				))
+			}
+		}
+		records = append(records,
 			types.NewEntryRecord(
-				types.NewAccountID(accounts.Odplatna),
+				types.NewAccountID(accounts.NiewydatkowanyDochod),
 				types.CreditBalance(incomeBase),
 			),
 			types.NewEntryRecord(
-				types.NewAccountID(accounts.NiewydatkowanyDochod),
-				types.CreditBalance(incomeBase),
+				types.NewAccountID(accounts.RozliczenieVAT, accounts.Nalezny),
+				types.CreditBalance(vatBase),
 			),
 		)
+		if mode, ok := coa.BalancedMode(); ok {
+			// Income of unrecorded sell is settled by the daily summary.
+			records = append(records, types.NewEntryRecord(
+				mode.Settlements,
+				types.DebitBalance(recordedBase.Add(vatBase)),
+			))
+		}
+		coa.AddEntry(s, records...)
 
 		for _, br := range bankRecords {
 			if br.Rate.EQ(incomeRate) {
 				continue
 			}
 
-			var amount types.AccountBalance
+			var diff types.Denom
+			var balanceFn func(amount types.Denom) types.AccountBalance
 			if incomeRate.GT(br.Rate) {
-				amount = types.DebitBalance(br.OriginalAmount.ToBase(incomeRate.Sub(br.Rate)))
+				diff = br.OriginalAmount.ToBase(incomeRate.Sub(br.Rate))
+				balanceFn = types.DebitBalance
 			} else {
-				amount = types.CreditBalance(br.OriginalAmount.ToBase(br.Rate.Sub(incomeRate)))
+				diff = br.OriginalAmount.ToBase(br.Rate.Sub(incomeRate))
+				balanceFn = types.CreditBalance
 			}
 
-			coa.AddEntry(types.NewCurrencyDiff(s, incomeRate, br),
-				types.NewEntryRecord(
-					types.NewAccountID(accounts.RozniceKursowe, accounts.Odplatna),
-					amount,
-				),
-			)
+			records := make([]types.EntryRecord, 0, len(s.Lines))
+			for i, diffPart := range diff.Allocate(weights...) {
+				records = append(records, types.NewEntryRecord(
+					types.NewAccountID(accounts.RozniceKursowe, incomeTypeToAccountPart(s.Lines[i].IncomeType)),
+					balanceFn(diffPart),
+				))
+			}
+			coa.AddEntry(types.NewCurrencyDiff(s, incomeRate, br), records...)
 		}
 	}
 
@@ -136,13 +191,74 @@ func (s *Sell) BookRecords(
 	return nil
 }
 
-func sellTypeToAccountID(sellType types.SellType) types.AccountID {
+// Amount returns total amount of the invoice.
+func (s *Sell) Amount() types.Denom {
+	amount := s.Lines[0].Amount
+	for _, line := range s.Lines[1:] {
+		amount = amount.Add(line.Amount)
+	}
+	return amount
+}
+
+// OutputVAT returns net amounts and output VAT of the invoice in base currency, grouped by VAT rate.
+func (s *Sell) OutputVAT(rates types.CurrencyRates) []types.VATAmount {
+	grossParts, vatParts, _ := s.lineAmounts(rates)
+	amounts := []types.VATAmount{}
+	for i, line := range s.Lines {
+		if line.VATRate == types.VATRateNotRelevant {
+			continue
+		}
+		amounts = types.AddVATAmount(amounts, types.VATAmount{
+			Rate: line.VATRate,
+			Net:  grossParts[i].Sub(vatParts[i]),
+			VAT:  vatParts[i],
+		})
+	}
+	return amounts
+}
+
+// lineAmounts returns gross amounts and VAT of invoice lines in base currency together with the rate used.
+func (s *Sell) lineAmounts(rates types.CurrencyRates) ([]types.Denom, []types.Denom, types.Number) {
+	incomeBase, incomeRate := rates.ToBase(s.Amount(), types.PreviousDay(s.Date))
+	vatParts := make([]types.Denom, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		if line.VATRate == types.VATRateNotRelevant {
+			vatParts = append(vatParts, types.BaseZero)
+			continue
+		}
+		vatParts = append(vatParts, line.VATRate.VAT(line.Amount).ToBase(incomeRate))
+	}
+	return incomeBase.Allocate(s.lineWeights()...), vatParts, incomeRate
+}
+
+// lineWeights returns amounts of invoice lines used to split amounts in base currency.
+func (s *Sell) lineWeights() []types.Number {
+	weights := make([]types.Number, 0, len(s.Lines))
+	for _, line := range s.Lines {
+		weights = append(weights, line.Amount.Amount.Abs())
+	}
+	return weights
+}
+
+func sellTypeToAccountID(sellType types.SellType, incomeType types.IncomeType) types.AccountID {
 	switch sellType {
 	case types.SellTypeRecorded:
-		return types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne, accounts.Odplatna)
+		return types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne,
+			incomeTypeToAccountPart(incomeType))
 	case types.SellTypeUnrecorded:
-		return types.NewAccountID(accounts.SprzedazNieewidencjonowana)
+		return types.NewAccountID(accounts.SprzedazNieewidencjonowana, incomeTypeToAccountPart(incomeType))
 	default:
 		panic("invalid sell type")
 	}
 }
+
+func incomeTypeToAccountPart(incomeType types.IncomeType) types.AccountIDPart {
+	switch incomeType {
+	case types.IncomeTypePaid:
+		return accounts.Odplatna
+	case types.IncomeTypeOther:
+		return accounts.Pozostale
+	default:
+		panic("invalid income type")
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/outofforest/uepik/v2/types"
 )
 
+var unrecordedIncomeParts = []types.AccountIDPart{accounts.Odplatna, accounts.Pozostale}
+
 // UnrecordedSell defines the unrecorded sell operation.
 type UnrecordedSell struct {
 	Contractor types.Contractor
@@ -46,17 +48,24 @@ func (us *UnrecordedSell) BookRecords(
 				Contractor: us.Contractor,
 			}
 
-			sum := types.BaseZero
-			for _, entry := range entries {
-				sum = sum.Add(entry.Amount.Credit)
-			}
-			coa.AddEntry(
-				source,
-				types.NewEntryRecord(
-					types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne, accounts.Odplatna),
+			records := make([]types.EntryRecord, 0, len(unrecordedIncomeParts)+1)
+			total := types.BaseZero
+			for _, part := range unrecordedIncomeParts {
+				sum := types.BaseZero
+				for _, entry := range entries {
+					sum = sum.Add(coa.Amount(types.NewAccountID(accounts.SprzedazNieewidencjonowana, part),
+						entry.ID).Credit)
+				}
+				total = total.Add(sum)
+				records = append(records, types.NewEntryRecord(
+					types.NewAccountID(accounts.PiK, accounts.Przychody, accounts.Operacyjne, part),
 					types.CreditBalance(sum),
-				),
-			)
+				))
+			}
+			if mode, ok := coa.BalancedMode(); ok {
+				records = append(records, types.NewEntryRecord(mode.Settlements, types.DebitBalance(total)))
+			}
+			coa.AddEntry(source, records...)
 
 			docs = append(docs, documents.GenerateUnrecordedSellDocument(source.Document, us.Contractor, entries))
 		}
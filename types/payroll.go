@@ -0,0 +1,236 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ContractType defines the type of civil-law contract.
+type ContractType string
+
+// Contract types.
+const (
+	ContractTypeMandate      ContractType = "mandate"
+	ContractTypeSpecificWork ContractType = "specificWork"
+)
+
+// PayrollPayee defines the party to which the payroll liability is paid.
+type PayrollPayee string
+
+// Payroll payees.
+const (
+	PayrollPayeeEmployee        PayrollPayee = "employee"
+	PayrollPayeeTaxOffice       PayrollPayee = "taxOffice"
+	PayrollPayeeSocialInsurance PayrollPayee = "socialInsurance"
+)
+
+// PayrollParameters defines the yearly rates used to compute payroll.
+type PayrollParameters struct {
+	PensionEmployee    Number
+	DisabilityEmployee Number
+	Sickness           Number
+	Health             Number
+	PensionEmployer    Number
+	DisabilityEmployer Number
+	Accident           Number
+	LabourFund         Number
+	GuaranteeFund      Number
+	TaxDeductibleCosts Number
+	Tax                Number
+}
+
+// Insurance defines the insurances the contract is subject to.
+type Insurance struct {
+	Social     bool
+	Sickness   bool
+	Health     bool
+	LabourFund bool
+}
+
+// Address is the address of residence required by tax declarations.
+type Address struct {
+	CountryCode  string
+	Province     string
+	County       string
+	Municipality string
+	Street       string
+	HouseNumber  string
+	FlatNumber   string
+	City         string
+	PostalCode   string
+}
+
+// String returns the address in the form used on documents.
+func (a Address) String() string {
+	street := a.Street
+	if street == "" {
+		street = a.City
+	}
+	number := a.HouseNumber
+	if a.FlatNumber != "" {
+		number += "/" + a.FlatNumber
+	}
+	return fmt.Sprintf("%s %s, %s %s", street, number, a.PostalCode, a.City)
+}
+
+// Employee is the natural person the civil-law contract is concluded with.
+type Employee struct {
+	FirstName string
+	LastName  string
+	PESEL     string
+	Address   Address
+}
+
+// Contractor returns the employee as the contractor.
+func (e Employee) Contractor() Contractor {
+	return Contractor{
+		Name:    e.FirstName + " " + e.LastName,
+		Address: e.Address.String(),
+		TaxID:   e.PESEL,
+	}
+}
+
+// BirthDate returns the date of birth encoded in PESEL.
+func (e Employee) BirthDate() time.Time {
+	return ParsePESEL(e.PESEL)
+}
+
+var peselWeights = []int{1, 3, 7, 9, 1, 3, 7, 9, 1, 3}
+
+// ValidatePESEL verifies the PESEL number.
+func ValidatePESEL(pesel string) error {
+	if len(pesel) != 11 {
+		return errors.Errorf("PESEL %q must have 11 digits", pesel)
+	}
+	sum := 0
+	for i, c := range pesel {
+		if c < '0' || c > '9' {
+			return errors.Errorf("PESEL %q must have 11 digits", pesel)
+		}
+		if i < len(peselWeights) {
+			sum += int(c-'0') * peselWeights[i]
+		}
+	}
+	if int(pesel[10]-'0') != (10-sum%10)%10 {
+		return errors.Errorf("invalid checksum of PESEL %q", pesel)
+	}
+	if ParsePESEL(pesel).IsZero() {
+		return errors.Errorf("invalid date of birth in PESEL %q", pesel)
+	}
+	return nil
+}
+
+// ParsePESEL returns the date of birth encoded in PESEL. Zero time is returned if the date is invalid.
+func ParsePESEL(pesel string) time.Time {
+	if len(pesel) < 6 {
+		return time.Time{}
+	}
+	digits := func(s string) int {
+		return int(s[0]-'0')*10 + int(s[1]-'0')
+	}
+	year, month, day := digits(pesel[0:2]), digits(pesel[2:4]), digits(pesel[4:6])
+	// Century is encoded in the month: 81-92 for 1800s, 1-12 for 1900s, 21-32 for 2000s and so on.
+	century := 1900
+	switch {
+	case month > 80:
+		century = 1800
+	default:
+		century += month / 20 * 100
+	}
+	month %= 20
+	date := time.Date(century+year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if month < 1 || date.Day() != day || date.Month() != time.Month(month) {
+		return time.Time{}
+	}
+	return date
+}
+
+// Payroll is the gross-to-net computation of the contract. Tax advance is withheld on the date of the payment to
+// the employee, withholding date is zero if the contract has not been paid yet.
+type Payroll struct {
+	Date               time.Time
+	Document           Document
+	Employee           Employee
+	WithholdingDate    time.Time
+	ContractType       ContractType
+	Gross              Denom
+	SocialEmployee     Denom
+	Health             Denom
+	TaxDeductibleCosts Denom
+	TaxBase            Denom
+	TaxAdvance         Denom
+	Net                Denom
+	SocialEmployer     Denom
+}
+
+// Cost returns the total cost of the contract borne by the employer.
+func (p Payroll) Cost() Denom {
+	return p.Gross.Add(p.SocialEmployer)
+}
+
+// SocialInsuranceDue returns the amount due to the social insurance institution.
+func (p Payroll) SocialInsuranceDue() Denom {
+	return p.SocialEmployee.Add(p.Health).Add(p.SocialEmployer)
+}
+
+// ComputePayroll computes gross-to-net of the contract.
+func ComputePayroll(
+	contractType ContractType,
+	gross Denom,
+	insurance Insurance,
+	params PayrollParameters,
+) Payroll {
+	if gross.Currency != BaseCurrency.Symbol {
+		panic("payroll must be denominated in base currency")
+	}
+	if !gross.GT(BaseZero) {
+		panic("gross amount must be positive")
+	}
+
+	switch contractType {
+	case ContractTypeMandate:
+		if !insurance.Social && (insurance.Sickness || insurance.LabourFund) {
+			panic("sickness insurance and labour fund require social insurance")
+		}
+	case ContractTypeSpecificWork:
+		if insurance != (Insurance{}) {
+			panic("specific-work contract is not subject to insurance")
+		}
+	default:
+		panic("invalid contract type")
+	}
+
+	p := Payroll{
+		ContractType:   contractType,
+		Gross:          gross,
+		SocialEmployee: BaseZero,
+		Health:         BaseZero,
+		SocialEmployer: BaseZero,
+	}
+
+	if insurance.Social {
+		p.SocialEmployee = gross.Percent(params.PensionEmployee).Add(gross.Percent(params.DisabilityEmployee))
+		p.SocialEmployer = gross.Percent(params.PensionEmployer).Add(gross.Percent(params.DisabilityEmployer)).
+			Add(gross.Percent(params.Accident))
+		if insurance.Sickness {
+			p.SocialEmployee = p.SocialEmployee.Add(gross.Percent(params.Sickness))
+		}
+		if insurance.LabourFund {
+			p.SocialEmployer = p.SocialEmployer.Add(gross.Percent(params.LabourFund)).
+				Add(gross.Percent(params.GuaranteeFund))
+		}
+	}
+
+	base := gross.Sub(p.SocialEmployee)
+	if insurance.Health {
+		p.Health = base.Percent(params.Health)
+	}
+	p.TaxDeductibleCosts = base.Percent(params.TaxDeductibleCosts)
+	p.TaxBase = base.Sub(p.TaxDeductibleCosts).RoundUnits()
+	p.TaxAdvance = p.TaxBase.Percent(params.Tax).RoundUnits()
+	p.Net = gross.Sub(p.SocialEmployee).Sub(p.Health).Sub(p.TaxAdvance)
+
+	return p
+}
@@ -0,0 +1,207 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+// payrollParameters2025 are the rates of 2025: pension 9.76%/9.76%, disability 1.5%/6.5%, sickness 2.45%,
+// accident 1.67%, labour fund 2.45%, guarantee fund 0.1%, health 9%, tax-deductible costs 20%, tax 12%.
+var payrollParameters2025 = PayrollParameters{
+	PensionEmployee:    NewPercent(9, 76),
+	DisabilityEmployee: NewPercent(1, 50),
+	Sickness:           NewPercent(2, 45),
+	Health:             NewPercent(9, 0),
+	PensionEmployer:    NewPercent(9, 76),
+	DisabilityEmployer: NewPercent(6, 50),
+	Accident:           NewPercent(1, 67),
+	LabourFund:         NewPercent(2, 45),
+	GuaranteeFund:      NewPercent(0, 10),
+	TaxDeductibleCosts: NewPercent(20, 0),
+	Tax:                NewPercent(12, 0),
+}
+
+func TestComputePayroll(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		contractType       ContractType
+		gross              string
+		insurance          Insurance
+		socialEmployee     string
+		health             string
+		taxDeductibleCosts string
+		taxBase            string
+		taxAdvance         string
+		net                string
+		socialEmployer     string
+	}{
+		{
+			name:               "mandate with all insurances",
+			contractType:       ContractTypeMandate,
+			gross:              "3000.00",
+			insurance:          Insurance{Social: true, Sickness: true, Health: true, LabourFund: true},
+			socialEmployee:     "411.30",
+			health:             "232.98",
+			taxDeductibleCosts: "517.74",
+			taxBase:            "2071.00",
+			taxAdvance:         "249.00",
+			net:                "2106.72",
+			socialEmployer:     "614.40",
+		},
+		{
+			name:               "mandate without sickness insurance and labour fund",
+			contractType:       ContractTypeMandate,
+			gross:              "3000.00",
+			insurance:          Insurance{Social: true, Health: true},
+			socialEmployee:     "337.80",
+			health:             "239.60",
+			taxDeductibleCosts: "532.44",
+			taxBase:            "2130.00",
+			taxAdvance:         "256.00",
+			net:                "2166.60",
+			socialEmployer:     "537.90",
+		},
+		{
+			name:               "mandate with rounding of contributions",
+			contractType:       ContractTypeMandate,
+			gross:              "1234.57",
+			insurance:          Insurance{Social: true, Sickness: true, Health: true},
+			socialEmployee:     "169.26",
+			health:             "95.88",
+			taxDeductibleCosts: "213.06",
+			taxBase:            "852.00",
+			taxAdvance:         "102.00",
+			net:                "867.43",
+			socialEmployer:     "221.36",
+		},
+		{
+			name:               "mandate without insurance",
+			contractType:       ContractTypeMandate,
+			gross:              "500.00",
+			socialEmployee:     "0.00",
+			health:             "0.00",
+			taxDeductibleCosts: "100.00",
+			taxBase:            "400.00",
+			taxAdvance:         "48.00",
+			net:                "452.00",
+			socialEmployer:     "0.00",
+		},
+		{
+			name:               "specific work",
+			contractType:       ContractTypeSpecificWork,
+			gross:              "1000.00",
+			socialEmployee:     "0.00",
+			health:             "0.00",
+			taxDeductibleCosts: "200.00",
+			taxBase:            "800.00",
+			taxAdvance:         "96.00",
+			net:                "904.00",
+			socialEmployer:     "0.00",
+		},
+		{
+			name:               "specific work with tax base rounded up from 50 groszy",
+			contractType:       ContractTypeSpecificWork,
+			gross:              "1000.63",
+			socialEmployee:     "0.00",
+			health:             "0.00",
+			taxDeductibleCosts: "200.13",
+			taxBase:            "801.00",
+			taxAdvance:         "96.00",
+			net:                "904.63",
+			socialEmployer:     "0.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := ComputePayroll(tt.contractType, denom(tt.gross), tt.insurance, payrollParameters2025)
+			assertDenoms(t,
+				[]string{
+					tt.socialEmployee, tt.health, tt.taxDeductibleCosts, tt.taxBase, tt.taxAdvance, tt.net,
+					tt.socialEmployer,
+				},
+				[]Denom{p.SocialEmployee, p.Health, p.TaxDeductibleCosts, p.TaxBase, p.TaxAdvance, p.Net,
+					p.SocialEmployer},
+			)
+		})
+	}
+}
+
+func TestComputePayrollPanics(t *testing.T) {
+	t.Parallel()
+
+	eur, err := ParseDenom("1000.00", EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		contractType ContractType
+		gross        Denom
+		insurance    Insurance
+	}{
+		{name: "foreign currency", contractType: ContractTypeMandate, gross: eur},
+		{name: "zero amount", contractType: ContractTypeMandate, gross: denom("0.00")},
+		{name: "negative amount", contractType: ContractTypeMandate, gross: denom("-100.00")},
+		{
+			name:         "sickness insurance without social insurance",
+			contractType: ContractTypeMandate,
+			gross:        denom("1000.00"),
+			insurance:    Insurance{Sickness: true},
+		},
+		{
+			name:         "insured specific work",
+			contractType: ContractTypeSpecificWork,
+			gross:        denom("1000.00"),
+			insurance:    Insurance{Health: true},
+		},
+		{name: "invalid contract type", contractType: "employment", gross: denom("1000.00")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, func() {
+				ComputePayroll(tt.contractType, tt.gross, tt.insurance, payrollParameters2025)
+			})
+		})
+	}
+}
+
+func TestValidatePESEL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pesel     string
+		valid     bool
+		birthDate time.Time
+	}{
+		{pesel: "85010112345", valid: true, birthDate: time.Date(1985, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{pesel: "90020254327", valid: true, birthDate: time.Date(1990, 2, 2, 0, 0, 0, 0, time.UTC)},
+		{pesel: "02271409867", valid: true, birthDate: time.Date(2002, 7, 14, 0, 0, 0, 0, time.UTC)},
+		{pesel: "90020254321"},
+		{pesel: "9002025432"},
+		{pesel: "9002025432a"},
+		{pesel: "90023054320"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pesel, func(t *testing.T) {
+			t.Parallel()
+
+			err := ValidatePESEL(tt.pesel)
+			if tt.valid != (err == nil) {
+				t.Fatalf("expected valid: %t, got error: %v", tt.valid, err)
+			}
+			if tt.valid && !ParsePESEL(tt.pesel).Equal(tt.birthDate) {
+				t.Fatalf("expected birth date %s, got %s", tt.birthDate, ParsePESEL(tt.pesel))
+			}
+		})
+	}
+}
@@ -24,6 +24,84 @@ const (
 	CostCategoryTypePaid         CostCategoryType = "paid"
 )
 
+// CostAllocation defines the part of the cost assigned to the tax type and category type.
+// Part is defined either by the fixed amount or by the percentage of the amount left after subtracting
+// all the fixed parts.
+type CostAllocation struct {
+	CostTaxType      CostTaxType
+	CostCategoryType CostCategoryType
+	Amount           Denom
+	Percentage       Number
+}
+
+// AllocateCost splits the cost into parts defined by allocations.
+func AllocateCost(amount Denom, allocations []CostAllocation) []Denom {
+	if len(allocations) == 0 {
+		panic("no cost allocations")
+	}
+
+	if amount.LT(NewDenom(amount.Currency)) {
+		// Credit notes are allocated in the same way as the documents they correct.
+		negAllocations := make([]CostAllocation, 0, len(allocations))
+		for _, a := range allocations {
+			if a.Amount != (Denom{}) {
+				a.Amount = a.Amount.Neg()
+			}
+			negAllocations = append(negAllocations, a)
+		}
+		parts := AllocateCost(amount.Neg(), negAllocations)
+		for i := range parts {
+			parts[i] = parts[i].Neg()
+		}
+		return parts
+	}
+
+	var zeroDenom Denom
+	hundred := NewPercent(100, 0)
+
+	parts := make([]Denom, len(allocations))
+	rest := amount
+	percentages := []Number{}
+	percentageIndexes := []int{}
+	percentageSum := NewPercent(0, 0)
+	for i, a := range allocations {
+		if a.Amount == zeroDenom {
+			if a.Percentage.LTE(NewPercent(0, 0)) || a.Percentage.GT(hundred) {
+				panic("invalid percentage of cost allocation")
+			}
+			percentages = append(percentages, a.Percentage)
+			percentageIndexes = append(percentageIndexes, i)
+			percentageSum = percentageSum.Add(a.Percentage)
+			continue
+		}
+		if a.Amount.Currency != amount.Currency {
+			panic("currency of cost allocation does not match")
+		}
+		parts[i] = a.Amount
+		rest = rest.Sub(a.Amount)
+	}
+
+	if rest.LT(NewDenom(amount.Currency)) {
+		panic("cost allocations exceed the amount")
+	}
+
+	if len(percentages) == 0 {
+		if !rest.Amount.IsZero() {
+			panic("cost allocations do not sum up to the amount")
+		}
+		return parts
+	}
+
+	if percentageSum.NEQ(hundred) {
+		panic("percentages of cost allocations do not sum up to 100")
+	}
+	for i, part := range rest.Allocate(percentages...) {
+		parts[percentageIndexes[i]] = part
+	}
+
+	return parts
+}
+
 // SellType is the sell type.
 type SellType string
 
@@ -33,6 +111,71 @@ const (
 	SellTypeUnrecorded = "unrecorded"
 )
 
+// IncomeType is the type of income coming from sell.
+type IncomeType string
+
+// Income types.
+const (
+	IncomeTypePaid  IncomeType = "paid"
+	IncomeTypeOther IncomeType = "other"
+)
+
+// InvoiceLine defines the line of the sell invoice.
+type InvoiceLine struct {
+	Description string
+	Amount      Denom
+	SellType    SellType
+	IncomeType  IncomeType
+	VATRate     VATRate
+}
+
+// DefaultDonorDisclosureLimit is the yearly total of donations from the donor above which the donor is disclosed
+// in CIT-D.
+var DefaultDonorDisclosureLimit = Denom{Currency: PLN, Amount: NewNumber(15000, 0, BaseCurrency.AmountPrecision)}
+
+// DefaultVATLimit returns the yearly sales limit of the subjective VAT exemption applicable in the year. The limit
+// is raised from 200 000 zł to 240 000 zł in 2026.
+func DefaultVATLimit(year int) Denom {
+	if year >= 2026 {
+		return Denom{Currency: PLN, Amount: NewNumber(240000, 0, BaseCurrency.AmountPrecision)}
+	}
+	return Denom{Currency: PLN, Amount: NewNumber(200000, 0, BaseCurrency.AmountPrecision)}
+}
+
+// VATLimitThresholds are the percentages of the VAT limit on which warning is reported.
+var VATLimitThresholds = []Number{NewPercent(80, 0), NewPercent(90, 0), NewPercent(100, 0)}
+
+// VATLimit defines the sales limit of the subjective VAT exemption.
+type VATLimit struct {
+	Amount        Denom
+	ActivityStart time.Time
+}
+
+// Effective returns the limit applicable in the year, proportional to the period of activity if it started
+// during the year.
+func (l VATLimit) Effective(year int) Denom {
+	var zeroDenom Denom
+	amount := l.Amount
+	if amount == zeroDenom {
+		amount = DefaultVATLimit(year)
+	}
+
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	activityStart := time.Date(l.ActivityStart.Year(), l.ActivityStart.Month(), l.ActivityStart.Day(), 0, 0, 0, 0,
+		time.UTC)
+	if !activityStart.After(yearStart) {
+		return amount
+	}
+	if !activityStart.Before(yearEnd) {
+		panic("activity starts after the year")
+	}
+
+	yearDays := uint64(yearEnd.Sub(yearStart).Hours() / 24)
+	activeDays := uint64(yearEnd.Sub(activityStart).Hours() / 24)
+	return amount.Mul(activeDays).Div(yearDays)
+}
+
 // Period defines date range for fiscal year.
 type Period struct {
 	Start time.Time
@@ -62,6 +205,17 @@ type Document struct {
 	ID        DocumentID
 	Date      time.Time
 	SheetName string
+
+	// Series and Number are set if the document is issued with automatic numbering.
+	Series string
+	Number uint64
+}
+
+// BankAccount defines the bank account of the company used to receive payments in the currency.
+type BankAccount struct {
+	Currency CurrencySymbol
+	Number   string
+	Bank     string
 }
 
 // Contractor defines contractor.
@@ -71,6 +225,29 @@ type Contractor struct {
 	TaxID   string
 }
 
+// PaymentReminders defines generation of payment reminders for overdue receivables. Reminders are generated if the
+// date is set. Interest is charged at the annual rate if it is set, otherwise statutory interest is charged.
+type PaymentReminders struct {
+	Date         time.Time
+	InterestRate Number
+}
+
+// LateInterest defines calculation of statutory interest on late receivables. Interest is calculated if the type is
+// set. If booking is enabled, interest notes are booked as financial income on dates of late payments.
+type LateInterest struct {
+	Type InterestType
+	Book bool
+}
+
+// LedgerSelection selects accounts for which general ledger is included in the report.
+type LedgerSelection struct {
+	All      bool
+	Accounts []AccountID
+}
+
+// Project identifies the programme or project the operation belongs to.
+type Project string
+
 // Due represents due.
 type Due struct {
 	Date   time.Time
@@ -85,6 +262,18 @@ type Payment struct {
 	Amount     Denom
 }
 
+// BankTransaction is the transaction imported from the bank statement. Amount is negative for outgoing transfers.
+type BankTransaction struct {
+	DocumentID          DocumentID
+	Index               uint64
+	Date                time.Time
+	Amount              Denom
+	Balance             Denom
+	Counterparty        Contractor
+	CounterpartyAccount string
+	Title               string
+}
+
 // Operation defines operation which might bee accounted.
 type Operation interface {
 	BankRecords() []*BankRecord
@@ -100,11 +289,25 @@ type ReportDocument struct {
 	Config   SheetConfig
 }
 
+// ExportDocument represents a document exported to a separate file.
+type ExportDocument struct {
+	FileName string
+	Template *template.Template
+	Data     any
+}
+
+// ExportFile is the rendered export document.
+type ExportFile struct {
+	FileName string
+	Content  string
+}
+
 // Report is the full report.
 type Report struct {
 	Currencies []Currency
 	Configs    []string
 	Documents  []string
+	Exports    []ExportFile
 }
 
 // BankRecord defines the properties of bank record.
@@ -130,12 +333,38 @@ func (r BankRecord) GetDate() time.Time {
 
 // FiscalYear defines fiscal year.
 type FiscalYear struct {
-	CompanyName    string
-	CompanyAddress string
-	CompanyTaxID   string
-	Period         Period
-	Init           Init
-	Operations     []Operation
+	CompanyName          string
+	CompanyAddress       string
+	CompanyTaxID         string
+	CompanyKRS           string
+	PublicBenefit        bool
+	TaxOfficeCode        string
+	VATLimit             VATLimit
+	Budget               Budget
+	Ledger               LedgerSelection
+	Chart                []*Account
+	ChartExtensions      []ChartExtension
+	BookingRules         []BookingRule
+	Balanced             bool
+	OpenItemsDates       []time.Time
+	Reminders            PaymentReminders
+	LateInterest         LateInterest
+	BankAccounts         []BankAccount
+	VATExemptionBasis    string
+	DonorDisclosureLimit Denom
+	BankTransactions     []BankTransaction
+	Period               Period
+	Init                 Init
+	Operations           []Operation
+}
+
+// EffectiveDonorDisclosureLimit returns the CIT-D disclosure limit applicable in the year.
+func (fy *FiscalYear) EffectiveDonorDisclosureLimit() Denom {
+	var zeroDenom Denom
+	if fy.DonorDisclosureLimit == zeroDenom {
+		return DefaultDonorDisclosureLimit
+	}
+	return fy.DonorDisclosureLimit
 }
 
 // BankReports returns bank reports.
@@ -175,6 +404,11 @@ func (fy *FiscalYear) BookRecords(
 ) []ReportDocument {
 	docs := []ReportDocument{}
 	for _, o := range fy.Operations {
+		if data, ok := o.(EntryDataSource); ok {
+			for _, br := range bankRecords[o] {
+				coa.BookBankRecord(NewSettlement(data, br), br)
+			}
+		}
 		docs = append(docs, o.BookRecords(fy.Period, coa, bankRecords[o], currencyRates)...)
 	}
 	for i := range docs {
@@ -363,6 +597,57 @@ func (cd *CurrencyDiff) GetNotes() string {
 	return cd.Data.GetNotes()
 }
 
+// GetProject returns project of the source operation.
+func (cd *CurrencyDiff) GetProject() Project {
+	return sourceProject(cd.Data)
+}
+
+// NewSettlement creates new settlement data source.
+func NewSettlement(data EntryDataSource, bankRecord *BankRecord) *Settlement {
+	return &Settlement{
+		Data:       data,
+		BankRecord: bankRecord,
+	}
+}
+
+// Settlement is the data source of liability settled by the bank record.
+type Settlement struct {
+	Data       EntryDataSource
+	BankRecord *BankRecord
+}
+
+// GetDate returns date.
+func (s *Settlement) GetDate() time.Time {
+	return s.BankRecord.Date
+}
+
+// GetDocument returns document.
+func (s *Settlement) GetDocument() Document {
+	return s.Data.GetDocument()
+}
+
+// GetContractor returns contractor.
+func (s *Settlement) GetContractor() Contractor {
+	return s.Data.GetContractor()
+}
+
+// GetNotes returns notes.
+func (s *Settlement) GetNotes() string {
+	return s.Data.GetNotes()
+}
+
+// GetProject returns project of the source operation.
+func (s *Settlement) GetProject() Project {
+	return sourceProject(s.Data)
+}
+
+func sourceProject(data EntryDataSource) Project {
+	if ps, ok := data.(projectSource); ok {
+		return ps.GetProject()
+	}
+	return ""
+}
+
 // SheetConfig stores sheet config.
 type SheetConfig struct {
 	Name       string
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllocateCost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		amount      string
+		allocations []CostAllocation
+		expected    []string
+	}{
+		{
+			name:   "percentages",
+			amount: "100.00",
+			allocations: []CostAllocation{
+				{Percentage: NewPercent(50, 0)},
+				{Percentage: NewPercent(50, 0)},
+			},
+			expected: []string{"50.00", "50.00"},
+		},
+		{
+			name:   "remainder to largest percentage",
+			amount: "100.01",
+			allocations: []CostAllocation{
+				{Percentage: NewPercent(33, 33)},
+				{Percentage: NewPercent(33, 34)},
+				{Percentage: NewPercent(33, 33)},
+			},
+			expected: []string{"33.33", "33.35", "33.33"},
+		},
+		{
+			name:   "amount and rest",
+			amount: "100.00",
+			allocations: []CostAllocation{
+				{Amount: denom("30.00")},
+				{Percentage: NewPercent(100, 0)},
+			},
+			expected: []string{"30.00", "70.00"},
+		},
+		{
+			name:   "amounts only",
+			amount: "100.00",
+			allocations: []CostAllocation{
+				{Amount: denom("40.00")},
+				{Amount: denom("60.00")},
+			},
+			expected: []string{"40.00", "60.00"},
+		},
+		{
+			name:   "credit note",
+			amount: "-100.00",
+			allocations: []CostAllocation{
+				{Amount: denom("-30.00")},
+				{Percentage: NewPercent(100, 0)},
+			},
+			expected: []string{"-30.00", "-70.00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertDenoms(t, tt.expected, AllocateCost(denom(tt.amount), tt.allocations))
+		})
+	}
+}
+
+func TestAllocateCostPanics(t *testing.T) {
+	t.Parallel()
+
+	eur, err := ParseDenom("10.00", EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		allocations []CostAllocation
+	}{
+		{name: "no allocations"},
+		{
+			name:        "zero percentage",
+			allocations: []CostAllocation{{Percentage: NewPercent(0, 0)}},
+		},
+		{
+			name: "percentages not summing up to 100",
+			allocations: []CostAllocation{
+				{Percentage: NewPercent(50, 0)},
+				{Percentage: NewPercent(40, 0)},
+			},
+		},
+		{
+			name:        "amounts exceeding cost",
+			allocations: []CostAllocation{{Amount: denom("120.00")}},
+		},
+		{
+			name:        "amounts not summing up to cost",
+			allocations: []CostAllocation{{Amount: denom("90.00")}},
+		},
+		{
+			name: "currency mismatch",
+			allocations: []CostAllocation{
+				{Amount: eur},
+				{Percentage: NewPercent(100, 0)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertPanics(t, func() {
+				AllocateCost(denom("100.00"), tt.allocations)
+			})
+		})
+	}
+}
+
+func TestVATLimitEffective(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		limit    VATLimit
+		year     int
+		expected string
+	}{
+		{name: "default 2025", year: 2025, expected: "200000.00"},
+		{name: "default 2026", year: 2026, expected: "240000.00"},
+		{name: "custom", limit: VATLimit{Amount: denom("150000.00")}, year: 2026, expected: "150000.00"},
+		{
+			name:     "activity started during the year",
+			limit:    VATLimit{ActivityStart: time.Date(2026, time.July, 2, 0, 0, 0, 0, time.UTC)},
+			year:     2026,
+			expected: "120328.77",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assertDenoms(t, []string{tt.expected}, []Denom{tt.limit.Effective(tt.year)})
+		})
+	}
+}
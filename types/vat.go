@@ -0,0 +1,81 @@
+package types
+
+import "github.com/shopspring/decimal"
+
+// VATRate is the VAT rate applied to the amount. Empty rate means the amount is not subject to VAT settlement.
+type VATRate string
+
+// VAT rates.
+const (
+	VATRate23          VATRate = "23"
+	VATRate8           VATRate = "8"
+	VATRate5           VATRate = "5"
+	VATRate0           VATRate = "0"
+	VATRateExempt      VATRate = "zw"
+	VATRateNotSubject  VATRate = "np"
+	VATRateNotRelevant VATRate = ""
+)
+
+// VATRates lists the VAT rates in the order used by registers.
+var VATRates = []VATRate{VATRate23, VATRate8, VATRate5, VATRate0, VATRateExempt, VATRateNotSubject}
+
+// Percent returns the percentage of the rate.
+func (r VATRate) Percent() Number {
+	switch r {
+	case VATRate23:
+		return NewPercent(23, 0)
+	case VATRate8:
+		return NewPercent(8, 0)
+	case VATRate5:
+		return NewPercent(5, 0)
+	case VATRate0, VATRateExempt, VATRateNotSubject:
+		return NewPercent(0, 0)
+	default:
+		panic("invalid VAT rate")
+	}
+}
+
+// VAT returns the VAT included in the gross amount, rounded to the precision of the currency.
+func (r VATRate) VAT(gross Denom) Denom {
+	percent := r.Percent()
+	return Denom{
+		Currency: gross.Currency,
+		Amount: newNumberFromDecimal(
+			gross.Amount.decimal.Mul(percent.decimal).
+				DivRound(decimal.New(100, 0).Add(percent.decimal), int32(gross.Amount.precision)),
+			gross.Amount.precision,
+		),
+	}
+}
+
+// PurchaseVATLine defines the gross amount of the purchase taxed with the VAT rate.
+type PurchaseVATLine struct {
+	Rate       VATRate
+	Gross      Denom
+	Deductible bool
+}
+
+// VATAmount is the net amount and VAT at the rate, in base currency.
+type VATAmount struct {
+	Rate       VATRate
+	Net        Denom
+	VAT        Denom
+	Deductible bool
+}
+
+// Gross returns gross amount.
+func (va VATAmount) Gross() Denom {
+	return va.Net.Add(va.VAT)
+}
+
+// AddVATAmount adds amount to the list, summing it up with the existing amount of the same rate and deductibility.
+func AddVATAmount(amounts []VATAmount, amount VATAmount) []VATAmount {
+	for i, a := range amounts {
+		if a.Rate == amount.Rate && a.Deductible == amount.Deductible {
+			amounts[i].Net = a.Net.Add(amount.Net)
+			amounts[i].VAT = a.VAT.Add(amount.VAT)
+			return amounts
+		}
+	}
+	return append(amounts, amount)
+}
@@ -2,11 +2,16 @@
 package uepik
 
 import (
+	"fmt"
 	"math"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/samber/lo"
 
+	"github.com/outofforest/uepik/v2/ksef"
+	"github.com/outofforest/uepik/v2/mt940"
 	"github.com/outofforest/uepik/v2/report"
 	"github.com/outofforest/uepik/v2/types"
 	"github.com/outofforest/uepik/v2/types/operations"
@@ -36,6 +41,74 @@ const (
 	Nieewidencjonowana = types.SellTypeUnrecorded
 )
 
+// Rodzaje przychodów ze sprzedaży.
+const (
+	PrzychodOdplatny  = types.IncomeTypePaid
+	PrzychodPozostaly = types.IncomeTypeOther
+)
+
+// Metody amortyzacji.
+const (
+	Liniowa     = types.DepreciationMethodLinear
+	Jednorazowa = types.DepreciationMethodOneOff
+	Degresywna  = types.DepreciationMethodReducing
+)
+
+// Stawki VAT.
+const (
+	VAT23 = types.VATRate23
+	VAT8  = types.VATRate8
+	VAT5  = types.VATRate5
+	VAT0  = types.VATRate0
+	VATZw = types.VATRateExempt
+	VATNp = types.VATRateNotSubject
+)
+
+// Rodzaje umów cywilnoprawnych.
+const (
+	UmowaZlecenie = types.ContractTypeMandate
+	UmowaODzielo  = types.ContractTypeSpecificWork
+)
+
+// Parametry2025 to stawki składek i podatku obowiązujące w 2025 roku.
+var Parametry2025 = ParametryWynagrodzen(
+	Procent(9, 76), Procent(1, 50), Procent(2, 45), Procent(9, 0),
+	Procent(9, 76), Procent(6, 50), Procent(1, 67), Procent(2, 45), Procent(0, 10),
+	Procent(20, 0), Procent(12, 0),
+)
+
+// Rodzaje odsetek ustawowych.
+const (
+	OdsetkiZaOpoznienie = types.InterestTypeDelay
+	OdsetkiHandlowe     = types.InterestTypeCommercial
+)
+
+// Wymiary analityczne.
+const (
+	WymiarProjekt    = types.DimensionProject
+	WymiarDotacja    = types.DimensionGrant
+	WymiarWydarzenie = types.DimensionEvent
+	WymiarMPK        = types.DimensionCostCentre
+)
+
+// Rodzaje kont.
+const (
+	KontoAktywow    = types.Assets
+	KontoPasywow    = types.Liabilities
+	KontoPrzychodow = types.Incomes
+	KontoKosztow    = types.Costs
+)
+
+// Rodzaje operacji, do których stosowane są reguły księgowania.
+var (
+	OperacjaSprzedaz    types.EntryDataSource = &operations.Sell{}
+	OperacjaZakup       types.EntryDataSource = &operations.Purchase{}
+	OperacjaDarowizna   types.EntryDataSource = &operations.Donation{}
+	OperacjaDotacja     types.EntryDataSource = &operations.Grant{}
+	OperacjaUmowa       types.EntryDataSource = &operations.Contract{}
+	OperacjaAmortyzacja types.EntryDataSource = &operations.DepreciationSource{}
+)
+
 var timeLocation = lo.Must(time.LoadLocation("Europe/Warsaw"))
 
 // Data tworzy datę.
@@ -61,6 +134,19 @@ func Kwota(c, u uint64, waluta types.CurrencySymbol) types.Denom {
 	}
 }
 
+// Ujemna zmienia znak kwoty, np. w fakturach korygujących.
+func Ujemna(kwota types.Denom) types.Denom {
+	return kwota.Neg()
+}
+
+// Procent tworzy wartość procentową.
+func Procent(c, u uint64) types.Number {
+	if u >= uint64(math.Pow10(types.PercentPrecision)) {
+		panic("Część ułamkowa jest zbyt duża.")
+	}
+	return types.NewPercent(c, u)
+}
+
 // Kurs tworzy kurs walutowy.
 func Kurs(waluta types.CurrencySymbol, data time.Time, c, u uint64) types.CurrencyRate {
 	currency := types.Currencies.Currency(waluta)
@@ -104,18 +190,208 @@ func Rok(
 		CompanyTaxID:   nipFirmy,
 		Period:         period,
 		Init:           bilansOtwarcia,
-		Operations:     Grupa(operacje...),
+		Operations:     numerujFaktury(Grupa(operacje...)),
 	}
 }
 
+// PodstawaZwolnieniaVAT ustawia podstawę prawną zwolnienia z VAT podawaną na wystawianych fakturach, np.
+// "art. 113 ust. 1 ustawy o VAT".
+func PodstawaZwolnieniaVAT(rok *types.FiscalYear, podstawa string) {
+	rok.VATExemptionBasis = podstawa
+}
+
+// RachunekBankowy definiuje rachunek bankowy podawany na fakturach wystawianych w danej walucie.
+func RachunekBankowy(rok *types.FiscalYear, waluta types.CurrencySymbol, numer, bank string) {
+	for _, r := range rok.BankAccounts {
+		if r.Currency == waluta {
+			panic("rachunek bankowy dla waluty jest już zdefiniowany")
+		}
+	}
+	rok.BankAccounts = append(rok.BankAccounts, types.BankAccount{
+		Currency: waluta,
+		Number:   numer,
+		Bank:     bank,
+	})
+}
+
+// DaneRejestrowe ustawia numer KRS organizacji oraz informację, czy posiada ona status organizacji pożytku
+// publicznego.
+func DaneRejestrowe(rok *types.FiscalYear, krs string, opp bool) {
+	rok.CompanyKRS = krs
+	rok.PublicBenefit = opp
+}
+
+// LimitDarczyncow ustawia łączną roczną kwotę darowizn od jednego darczyńcy, powyżej której darczyńca jest
+// wykazywany w załączniku CIT-D. Domyślnie stosowany jest limit 15 000 zł.
+func LimitDarczyncow(rok *types.FiscalYear, limit types.Denom) {
+	if limit.Currency != types.PLN {
+		panic("limit musi być określony w PLN")
+	}
+	rok.DonorDisclosureLimit = limit
+}
+
+// KodUrzeduSkarbowego ustawia kod urzędu skarbowego, do którego składane są deklaracje.
+func KodUrzeduSkarbowego(rok *types.FiscalYear, kod string) {
+	rok.TaxOfficeCode = kod
+}
+
+// LimitVAT ustawia roczny limit zwolnienia podmiotowego z VAT oraz datę rozpoczęcia działalności, jeśli nastąpiło
+// ono w trakcie roku. Domyślnie stosowany jest limit ustawowy za cały rok: 200 000 zł, a od 2026 roku 240 000 zł.
+func LimitVAT(rok *types.FiscalYear, limit types.Denom, rozpoczecieDzialalnosci time.Time) {
+	if limit.Currency != types.BaseCurrency.Symbol {
+		panic("nieprawidłowa waluta limitu VAT")
+	}
+	rok.VATLimit = types.VATLimit{
+		Amount:        limit,
+		ActivityStart: rozpoczecieDzialalnosci,
+	}
+}
+
+// Budzet dodaje pozycje do rocznego budżetu.
+func Budzet(rok *types.FiscalYear, pozycje ...types.BudgetLine) {
+	rok.Budget = append(rok.Budget, pozycje...)
+}
+
+// PozycjaBudzetu definiuje kwoty budżetowane na koncie w poszczególnych miesiącach, opcjonalnie ograniczone do
+// wymiarów analitycznych.
+func PozycjaBudzetu(
+	nazwa string,
+	konto types.AccountID,
+	kwoty map[time.Month]types.Denom,
+	wymiary ...types.Dimension,
+) types.BudgetLine {
+	for _, kwota := range kwoty {
+		if kwota.Currency != types.PLN {
+			panic("nieprawidłowa waluta kwoty budżetu")
+		}
+	}
+	return types.BudgetLine{
+		Name:       nazwa,
+		AccountID:  konto,
+		Dimensions: wymiary,
+		Amounts:    kwoty,
+	}
+}
+
+// Miesiecznie definiuje kwoty budżetu w kolejnych miesiącach, począwszy od stycznia.
+func Miesiecznie(kwoty ...types.Denom) map[time.Month]types.Denom {
+	if len(kwoty) > 12 {
+		panic("zbyt wiele kwot miesięcznych")
+	}
+	result := make(map[time.Month]types.Denom, len(kwoty))
+	for i, kwota := range kwoty {
+		result[time.Month(i+1)] = kwota
+	}
+	return result
+}
+
+// Rocznie dzieli roczną kwotę budżetu równo na wszystkie miesiące.
+func Rocznie(kwota types.Denom) map[time.Month]types.Denom {
+	weights := make([]types.Number, 0, 12)
+	for range 12 {
+		weights = append(weights, types.NewNumber(1, 0, 0))
+	}
+	return Miesiecznie(kwota.Allocate(weights...)...)
+}
+
+// KsiegaGlowna dołącza do raportu księgę główną wybranych kont. Jeśli nie wskazano kont, dołączana jest księga
+// wszystkich kont.
+func KsiegaGlowna(rok *types.FiscalYear, konta ...types.AccountID) {
+	if len(konta) == 0 {
+		rok.Ledger.All = true
+		return
+	}
+	rok.Ledger.Accounts = append(rok.Ledger.Accounts, konta...)
+}
+
+// PlanKont zastępuje domyślny plan kont własnym. Plan musi zawierać wszystkie konta planu domyślnego, na których
+// księgowane są operacje, może je rozszerzać o własne konta.
+func PlanKont(rok *types.FiscalYear, konta ...*types.Account) {
+	rok.Chart = konta
+}
+
+// DodajKonto dodaje własne konto do planu kont pod kontem nadrzędnym.
+func DodajKonto(rok *types.FiscalYear, nadrzedne types.AccountID, konto *types.Account) {
+	rok.ChartExtensions = append(rok.ChartExtensions, types.ChartExtension{
+		Parent:  nadrzedne,
+		Account: konto,
+	})
+}
+
+// NoweKonto definiuje konto. Numer jest segmentem numeru konta dołączanym do numeru konta nadrzędnego.
+func NoweKonto(
+	id types.AccountIDPart,
+	numer, nazwa, opis string,
+	rodzaj types.AccountType,
+	zrodla []reflect.Type,
+	podkonta ...*types.Account,
+) *types.Account {
+	return types.NewAccount(id, types.NewAccountLabel(numer, nazwa, opis), rodzaj, zrodla, podkonta...)
+}
+
+// KontoPozabilansowe oznacza konto jako ewidencję pozabilansową, nieuwzględnianą przy kontroli bilansowania zapisów.
+func KontoPozabilansowe(konto *types.Account) *types.Account {
+	return types.OffBalance(konto)
+}
+
+// PelnaKsiegowosc włącza księgowanie podwójne, w którym suma zapisów Wn każdej operacji musi być równa sumie zapisów
+// Ma. Środki na rachunkach bankowych, należności i zobowiązania księgowane są na kontach bilansowych, a operacje
+// niezbilansowane wykazywane są w raporcie.
+func PelnaKsiegowosc(rok *types.FiscalYear) {
+	rok.Balanced = true
+}
+
+// Zrodla określa rodzaje operacji, które mogą być księgowane na koncie.
+func Zrodla(rodzaje ...types.EntryDataSource) []reflect.Type {
+	return types.ValidSources(rodzaje...)
+}
+
+// WszystkieZrodla oznacza, że na koncie mogą być księgowane wszystkie rodzaje operacji.
+func WszystkieZrodla() []reflect.Type {
+	return types.AllValid()
+}
+
+// RegulaKsiegowania przekierowuje zapisy operacji danego rodzaju z konta domyślnego na inne konto, opcjonalnie tylko
+// dla operacji o wskazanych wymiarach analitycznych. Reguły stosowane są w kolejności definiowania.
+func RegulaKsiegowania(
+	rok *types.FiscalYear,
+	nazwa string,
+	zrodla []reflect.Type,
+	z, do types.AccountID,
+	wymiary ...types.Dimension,
+) {
+	rok.BookingRules = append(rok.BookingRules, types.BookingRule{
+		Name:       nazwa,
+		Sources:    zrodla,
+		From:       z,
+		To:         do,
+		Dimensions: wymiary,
+	})
+}
+
+// Konto tworzy identyfikator konta.
+func Konto(czesci ...types.AccountIDPart) types.AccountID {
+	return types.NewAccountID(czesci...)
+}
+
+// Wymiar tworzy wymiar analityczny.
+func Wymiar(klucz types.DimensionKey, wartosc string) types.Dimension {
+	return types.NewDimension(klucz, wartosc)
+}
+
 // BilansOtwarcia tworzy bilans otwarcia roku.
-func BilansOtwarcia(niewydanyZysk types.Denom, waluty types.InitCurrencies) types.Init {
+func BilansOtwarcia(
+	niewydanyZysk types.Denom,
+	waluty types.InitCurrencies,
+	srodkiTrwale ...types.OpeningFixedAsset,
+) types.Init {
 	if niewydanyZysk.Currency != types.BaseCurrency.Symbol {
 		panic("nieprawidłowa waluta dla niewydanego zysku")
 	}
 	return types.Init{
 		UnspentProfit: niewydanyZysk,
 		Currencies:    waluty,
+		FixedAssets:   srodkiTrwale,
 	}
 }
 
@@ -165,6 +441,40 @@ func Dokument(numer types.DocumentID, data time.Time) types.Document {
 	}
 }
 
+// Faktura tworzy dokument sprzedaży numerowany automatycznie w serii. Numery, np. FV/1/2025, nadawane są w Rok
+// kolejno w ramach serii i roku według dat dokumentów. Dla sprzedaży z takim dokumentem generowana jest faktura.
+func Faktura(seria string, data time.Time) types.Document {
+	if seria == "" {
+		panic("seria faktury jest pusta")
+	}
+	return types.Document{
+		Date:   data,
+		Series: seria,
+	}
+}
+
+func numerujFaktury(ops []types.Operation) []types.Operation {
+	var faktury []*operations.Sell
+	for _, op := range ops {
+		if sell, ok := op.(*operations.Sell); ok && sell.Document.Series != "" {
+			faktury = append(faktury, sell)
+		}
+	}
+	sort.SliceStable(faktury, func(i, j int) bool {
+		return faktury[i].Document.Date.Before(faktury[j].Document.Date)
+	})
+
+	numery := map[string]uint64{}
+	for _, sell := range faktury {
+		rok := sell.Document.Date.Year()
+		klucz := fmt.Sprintf("%s/%d", sell.Document.Series, rok)
+		numery[klucz]++
+		sell.Document.Number = numery[klucz]
+		sell.Document.ID = types.DocumentID(fmt.Sprintf("%s/%d/%d", sell.Document.Series, sell.Document.Number, rok))
+	}
+	return ops
+}
+
 // Kontrahent definiuje kontrahenta.
 func Kontrahent(nazwa, adres, nip string) types.Contractor {
 	return types.Contractor{
@@ -187,6 +497,17 @@ func Naleznosci(naleznosci ...types.Due) []types.Due {
 	return naleznosci
 }
 
+func sumaNaleznosci(naleznosci []types.Due) types.Denom {
+	if len(naleznosci) == 0 {
+		panic("brak zdefiniowanych należności ze sprzedaży")
+	}
+	kwota := naleznosci[0].Amount
+	for _, n := range naleznosci[1:] {
+		kwota = kwota.Add(n.Amount)
+	}
+	return kwota
+}
+
 // Platnosc definiuje płatność.
 func Platnosc(dokument types.DocumentID, data time.Time, index uint64, kwota types.Denom) types.Payment {
 	return types.Payment{
@@ -197,6 +518,23 @@ func Platnosc(dokument types.DocumentID, data time.Time, index uint64, kwota typ
 	}
 }
 
+// WyciagMT940 wczytuje wyciąg bankowy w formacie MT940. Transakcje otrzymują numer dokumentu i kolejne numery
+// pozycji, a w raporcie są uzgadniane z płatnościami. Dla transakcji bez płatności proponowane są operacje.
+func WyciagMT940(rok *types.FiscalYear, dokument types.DocumentID, wyciag []byte) {
+	var index uint64
+	for _, s := range lo.Must(mt940.Parse(wyciag, timeLocation)) {
+		for _, t := range s.Transactions {
+			if !rok.Period.Contains(t.Date) {
+				panic(fmt.Sprintf("transakcja z dnia %s spoza roku", t.Date.Format(time.DateOnly)))
+			}
+			index++
+			t.DocumentID = dokument
+			t.Index = index
+			rok.BankTransactions = append(rok.BankTransactions, t)
+		}
+	}
+}
+
 // Platnosci definiuje płatności.
 func Platnosci(platnosci ...types.Payment) []types.Payment {
 	return platnosci
@@ -231,6 +569,123 @@ func Darowizna(
 	}}
 }
 
+// Dotacja definiuje transzę dotacji otrzymanej na działalność statutową.
+func Dotacja(
+	dokument types.Document,
+	kontrahent types.Contractor,
+	platnosc types.Payment,
+	opis string,
+) []types.Operation {
+	return []types.Operation{&operations.Grant{
+		Document:   dokument,
+		Contractor: kontrahent,
+		Payment:    platnosc,
+		Notes:      opis,
+	}}
+}
+
+// Korekta oznacza sprzedaż lub zakup jako fakturę korygującą dokument o wskazanym numerze. Kwoty korekty zmniejszającej
+// są ujemne.
+func Korekta(korygowany types.DocumentID, operacje ...[]types.Operation) []types.Operation {
+	if korygowany == "" {
+		panic("brak numeru korygowanego dokumentu")
+	}
+	ops := Grupa(operacje...)
+	for _, op := range ops {
+		switch o := op.(type) {
+		case *operations.Sell:
+			zero := types.NewDenom(o.Lines[0].Amount.Currency)
+			for _, l := range o.Lines[1:] {
+				if l.Amount.LT(zero) != o.Lines[0].Amount.LT(zero) {
+					panic("pozycje korekty muszą mieć ten sam znak")
+				}
+			}
+			o.CorrectedDocument = korygowany
+		case *operations.Purchase:
+			o.CorrectedDocument = korygowany
+		default:
+			panic("korekta dotyczy tylko sprzedaży i zakupu")
+		}
+	}
+	return ops
+}
+
+// TerminPlatnosci ustawia termin płatności zakupów. Domyślnie terminem płatności jest data zakupu.
+func TerminPlatnosci(termin time.Time, operacje ...[]types.Operation) []types.Operation {
+	ops := Grupa(operacje...)
+	for _, op := range ops {
+		purchase, ok := op.(*operations.Purchase)
+		if !ok {
+			panic("termin płatności można ustawić tylko dla zakupu")
+		}
+		if termin.Before(purchase.Date) {
+			panic("termin płatności jest wcześniejszy niż data zakupu")
+		}
+		purchase.DueDate = termin
+	}
+	return ops
+}
+
+// StopaUstawowa oznacza naliczanie w wezwaniach do zapłaty odsetek ustawowych zamiast odsetek według stałej stopy.
+var StopaUstawowa = types.NewPercent(0, 0)
+
+// WezwaniaDoZaplaty generuje wezwania do zapłaty przeterminowanych należności na wskazany dzień. Odsetki naliczane są
+// według podanej stopy rocznej. Dla StopaUstawowa naliczane są odsetki ustawowe rodzaju wybranego w OdsetkiUstawowe,
+// a jeśli ich nie włączono, odsetki w transakcjach handlowych od kontrahentów posiadających NIP i odsetki za
+// opóźnienie od pozostałych.
+func WezwaniaDoZaplaty(rok *types.FiscalYear, naDzien time.Time, odsetki types.Number) {
+	rok.Reminders = types.PaymentReminders{
+		Date:         naDzien,
+		InterestRate: odsetki,
+	}
+}
+
+// OdsetkiUstawowe włącza naliczanie odsetek ustawowych od należności zapłaconych po terminie lub niezapłaconych.
+// Jeżeli włączone jest księgowanie, noty odsetkowe ujmowane są jako przychody finansowe na dzień zapłaty.
+func OdsetkiUstawowe(rok *types.FiscalYear, rodzaj types.InterestType, ksiegowanie bool) {
+	rok.LateInterest = types.LateInterest{
+		Type: rodzaj,
+		Book: ksiegowanie,
+	}
+}
+
+// RozrachunkiNaDzien dołącza do raportu zestawienie nierozliczonych należności i zobowiązań na wskazany dzień.
+func RozrachunkiNaDzien(rok *types.FiscalYear, data time.Time) {
+	rok.OpenItemsDates = append(rok.OpenItemsDates, data)
+}
+
+// Projekt przypisuje operacje do projektu (programu) działalności statutowej.
+func Projekt(nazwa string, operacje ...[]types.Operation) []types.Operation {
+	if nazwa == "" {
+		panic("nazwa projektu jest pusta")
+	}
+	ops := Grupa(operacje...)
+	for _, op := range ops {
+		var project *types.Project
+		switch o := op.(type) {
+		case *operations.Sell:
+			project = &o.Project
+		case *operations.Purchase:
+			project = &o.Project
+		case *operations.Donation:
+			project = &o.Project
+		case *operations.Grant:
+			project = &o.Project
+		case *operations.FixedAsset:
+			project = &o.Project
+		case *operations.Contract:
+			project = &o.Project
+		default:
+			continue
+		}
+		if *project != "" {
+			panic("operacja jest już przypisana do projektu")
+		}
+		*project = types.Project(nazwa)
+	}
+	return ops
+}
+
 // Sprzedaz definiuje sprzedaż.
 func Sprzedaz(
 	data time.Time,
@@ -241,18 +696,77 @@ func Sprzedaz(
 	rodzaj types.SellType,
 	opis string,
 ) []types.Operation {
-	if len(naleznosci) == 0 {
-		panic("brak zdefiniowanych należności ze sprzedaży")
-	}
-	return []types.Operation{&operations.Sell{
+	return SprzedazPozycje(data, dokument, kontrahent, naleznosci, platnosci,
+		Pozycje(Pozycja(opis, sumaNaleznosci(naleznosci), rodzaj, PrzychodOdplatny)), opis)
+}
+
+// SprzedazPozycje definiuje sprzedaż składającą się z wielu pozycji.
+func SprzedazPozycje(
+	data time.Time,
+	dokument types.Document,
+	kontrahent types.Contractor,
+	naleznosci []types.Due,
+	platnosci []types.Payment,
+	pozycje []types.InvoiceLine,
+	opis string,
+) []types.Operation {
+	sell := &operations.Sell{
 		Date:       data,
 		Document:   dokument,
 		Contractor: kontrahent,
 		Dues:       naleznosci,
 		Payments:   platnosci,
-		Type:       rodzaj,
+		Lines:      pozycje,
 		Notes:      opis,
-	}}
+	}
+
+	if sumaNaleznosci(naleznosci).NEQ(sell.Amount()) {
+		panic("suma należności różni się od sumy pozycji sprzedaży")
+	}
+	for _, p := range pozycje[1:] {
+		if (p.VATRate == types.VATRateNotRelevant) != (pozycje[0].VATRate == types.VATRateNotRelevant) {
+			panic("stawka VAT musi być określona dla wszystkich pozycji sprzedaży lub dla żadnej")
+		}
+	}
+
+	return []types.Operation{sell}
+}
+
+// Pozycje definiuje pozycje sprzedaży.
+func Pozycje(pozycje ...types.InvoiceLine) []types.InvoiceLine {
+	if len(pozycje) == 0 {
+		panic("brak zdefiniowanych pozycji sprzedaży")
+	}
+	return pozycje
+}
+
+// Pozycja definiuje pozycję sprzedaży.
+func Pozycja(
+	opis string,
+	kwota types.Denom,
+	rodzaj types.SellType,
+	przychod types.IncomeType,
+) types.InvoiceLine {
+	return types.InvoiceLine{
+		Description: opis,
+		Amount:      kwota,
+		SellType:    rodzaj,
+		IncomeType:  przychod,
+	}
+}
+
+// PozycjaVAT definiuje pozycję sprzedaży opodatkowaną VAT. Kwota jest kwotą brutto.
+func PozycjaVAT(
+	opis string,
+	kwotaBrutto types.Denom,
+	stawka types.VATRate,
+	rodzaj types.SellType,
+	przychod types.IncomeType,
+) types.InvoiceLine {
+	stawka.Percent()
+	line := Pozycja(opis, kwotaBrutto, rodzaj, przychod)
+	line.VATRate = stawka
+	return line
 }
 
 // Zakup definiuje zakup.
@@ -266,7 +780,193 @@ func Zakup(
 	typPozytku types.CostCategoryType,
 	opis string,
 ) []types.Operation {
+	return ZakupZPodzialem(data, dokument, kontrahent, kwota, platnosci,
+		Podzial(CzescProcentowa(typPodatkowy, typPozytku, Procent(100, 0))), opis)
+}
+
+// ZakupZPodzialem definiuje zakup, którego koszt jest rozdzielony pomiędzy różne typy podatkowe i rodzaje
+// działalności.
+func ZakupZPodzialem(
+	data time.Time,
+	dokument types.Document,
+	kontrahent types.Contractor,
+	kwota types.Denom,
+	platnosci []types.Payment,
+	podzial []types.CostAllocation,
+	opis string,
+) []types.Operation {
+	types.AllocateCost(kwota, podzial)
 	return []types.Operation{&operations.Purchase{
+		Date:        data,
+		Document:    dokument,
+		Contractor:  kontrahent,
+		Amount:      kwota,
+		Payments:    platnosci,
+		Allocations: podzial,
+		Notes:       opis,
+	}}
+}
+
+// ZakupVAT definiuje zakup opodatkowany VAT. Odliczony VAT naliczony nie jest kosztem.
+func ZakupVAT(
+	data time.Time,
+	dokument types.Document,
+	kontrahent types.Contractor,
+	kwota types.Denom,
+	platnosci []types.Payment,
+	podzial []types.CostAllocation,
+	vat []types.PurchaseVATLine,
+	opis string,
+) []types.Operation {
+	if len(vat) == 0 {
+		panic("brak zdefiniowanych stawek VAT")
+	}
+	suma := types.NewDenom(kwota.Currency)
+	for _, v := range vat {
+		suma = suma.Add(v.Gross)
+	}
+	if suma.NEQ(kwota) {
+		panic("suma kwot VAT różni się od kwoty zakupu")
+	}
+
+	zakup := ZakupZPodzialem(data, dokument, kontrahent, kwota, platnosci, podzial, opis)
+	zakup[0].(*operations.Purchase).VATLines = vat
+	return zakup
+}
+
+// ZakupKSeF definiuje zakup na podstawie faktury ustrukturyzowanej otrzymanej z KSeF (plik XML w formacie FA).
+// Sprzedawca, numer i data faktury, kwoty według stawek VAT, termin płatności i korygowany dokument są odczytywane
+// z faktury.
+func ZakupKSeF(
+	faktura []byte,
+	platnosci []types.Payment,
+	podzial []types.CostAllocation,
+	odliczenieVAT bool,
+	opis string,
+) []types.Operation {
+	f := lo.Must(ksef.Parse(faktura, timeLocation))
+	for i := range f.VATLines {
+		f.VATLines[i].Deductible = odliczenieVAT && f.VATLines[i].Rate.Percent().GT(types.NewPercent(0, 0))
+	}
+
+	zakup := ZakupVAT(f.Document.Date, f.Document, f.Seller, f.Amount, platnosci, podzial, f.VATLines, opis)
+	if !f.DueDate.IsZero() {
+		zakup = TerminPlatnosci(f.DueDate, zakup)
+	}
+	if f.CorrectedDocument != "" {
+		zakup = Korekta(f.CorrectedDocument, zakup)
+	}
+	return zakup
+}
+
+// StawkiVAT grupuje kwoty zakupu według stawek VAT.
+func StawkiVAT(stawki ...types.PurchaseVATLine) []types.PurchaseVATLine {
+	return stawki
+}
+
+// KwotaVAT definiuje kwotę brutto zakupu opodatkowaną stawką VAT, z odliczeniem VAT naliczonego lub bez.
+func KwotaVAT(stawka types.VATRate, kwotaBrutto types.Denom, odliczenie bool) types.PurchaseVATLine {
+	stawka.Percent()
+	return types.PurchaseVATLine{
+		Rate:       stawka,
+		Gross:      kwotaBrutto,
+		Deductible: odliczenie,
+	}
+}
+
+// Podzial definiuje podział kosztu.
+func Podzial(czesci ...types.CostAllocation) []types.CostAllocation {
+	if len(czesci) == 0 {
+		panic("brak zdefiniowanych części kosztu")
+	}
+	return czesci
+}
+
+// CzescKwotowa definiuje część kosztu o stałej kwocie.
+func CzescKwotowa(
+	typPodatkowy types.CostTaxType,
+	typPozytku types.CostCategoryType,
+	kwota types.Denom,
+) types.CostAllocation {
+	return types.CostAllocation{
+		CostTaxType:      typPodatkowy,
+		CostCategoryType: typPozytku,
+		Amount:           kwota,
+	}
+}
+
+// CzescProcentowa definiuje część kosztu jako procent kwoty pozostałej po odjęciu części kwotowych.
+func CzescProcentowa(
+	typPodatkowy types.CostTaxType,
+	typPozytku types.CostCategoryType,
+	procent types.Number,
+) types.CostAllocation {
+	return types.CostAllocation{
+		CostTaxType:      typPodatkowy,
+		CostCategoryType: typPozytku,
+		Percentage:       procent,
+	}
+}
+
+// Wyposazenie oznacza zakup jako zakup wyposażenia ujmowanego w ewidencji wyposażenia.
+func Wyposazenie(zakup []types.Operation, przedmioty ...types.EquipmentItem) []types.Operation {
+	if len(przedmioty) == 0 {
+		panic("brak zdefiniowanych przedmiotów wyposażenia")
+	}
+	if len(zakup) != 1 {
+		panic("wyposażenie musi dotyczyć pojedynczego zakupu")
+	}
+	purchase, ok := zakup[0].(*operations.Purchase)
+	if !ok {
+		panic("wyposażenie musi dotyczyć zakupu")
+	}
+	purchase.Equipment = append(purchase.Equipment, przedmioty...)
+	return zakup
+}
+
+// Przedmiot definiuje przedmiot wyposażenia.
+func Przedmiot(
+	nrInwentarzowy, nazwa string,
+	ilosc uint64,
+	wartosc types.Denom,
+	miejsce, osobaOdpowiedzialna string,
+) types.EquipmentItem {
+	return types.EquipmentItem{
+		InventoryNumber:   nrInwentarzowy,
+		Name:              nazwa,
+		Quantity:          ilosc,
+		Value:             wartosc,
+		Location:          miejsce,
+		ResponsiblePerson: osobaOdpowiedzialna,
+	}
+}
+
+// Likwidacja definiuje likwidację przedmiotu wyposażenia.
+func Likwidacja(data time.Time, dokument types.Document, nrInwentarzowy, powod string) []types.Operation {
+	return []types.Operation{&operations.EquipmentDisposal{
+		Disposal: types.EquipmentDisposal{
+			Date:            data,
+			Document:        dokument,
+			InventoryNumber: nrInwentarzowy,
+			Reason:          powod,
+		},
+	}}
+}
+
+// SrodekTrwaly definiuje zakup środka trwałego, którego koszt jest rozliczany przez odpisy amortyzacyjne.
+// W kolejnych latach środek trwały jest amortyzowany bez ponownego ujmowania zakupu.
+func SrodekTrwaly(
+	data time.Time,
+	dokument types.Document,
+	kontrahent types.Contractor,
+	kwota types.Denom,
+	platnosci []types.Payment,
+	typPodatkowy types.CostTaxType,
+	typPozytku types.CostCategoryType,
+	amortyzacja types.FixedAsset,
+	opis string,
+) []types.Operation {
+	return []types.Operation{&operations.FixedAsset{
 		Date:             data,
 		Document:         dokument,
 		Contractor:       kontrahent,
@@ -274,10 +974,184 @@ func Zakup(
 		Payments:         platnosci,
 		CostTaxType:      typPodatkowy,
 		CostCategoryType: typPozytku,
+		Asset:            amortyzacja,
+		Notes:            opis,
+	}}
+}
+
+// SrodekTrwalyBO definiuje środek trwały nabyty przed rokiem obrotowym, ujęty w bilansie otwarcia. Jest
+// potrzebny tylko wtedy, gdy rok nabycia nie jest prowadzony w programie.
+func SrodekTrwalyBO(
+	dokument types.Document,
+	wartoscPoczatkowa types.Denom,
+	typPodatkowy types.CostTaxType,
+	typPozytku types.CostCategoryType,
+	projekt string,
+	amortyzacja types.FixedAsset,
+) types.OpeningFixedAsset {
+	if wartoscPoczatkowa.Currency != types.BaseCurrency.Symbol {
+		panic("nieprawidłowa waluta dla wartości początkowej")
+	}
+	amortyzacja.Date = dokument.Date
+	amortyzacja.Document = dokument
+	amortyzacja.InitialValue = wartoscPoczatkowa
+	return types.OpeningFixedAsset{
+		Asset:            amortyzacja,
+		CostTaxType:      typPodatkowy,
+		CostCategoryType: typPozytku,
+		Project:          types.Project(projekt),
+	}
+}
+
+// Amortyzacja definiuje sposób amortyzacji środka trwałego.
+func Amortyzacja(
+	nrInwentarzowy, nazwa, grupaKST string,
+	metoda types.DepreciationMethod,
+	stawka types.Number,
+	miesiacRozpoczecia time.Time,
+) types.FixedAsset {
+	if metoda != types.DepreciationMethodOneOff && !stawka.GT(types.NewPercent(0, 0)) {
+		panic("nieprawidłowa stawka amortyzacji")
+	}
+	return types.FixedAsset{
+		InventoryNumber: nrInwentarzowy,
+		Name:            nazwa,
+		KSTGroup:        grupaKST,
+		Method:          metoda,
+		Rate:            stawka,
+		StartMonth:      miesiacRozpoczecia,
+	}
+}
+
+// ParametryWynagrodzen definiuje roczne stawki składek i podatku stosowane do wyliczenia wynagrodzeń.
+func ParametryWynagrodzen(
+	emerytalnaPracownik, rentowaPracownik, chorobowa, zdrowotna types.Number,
+	emerytalnaPlatnik, rentowaPlatnik, wypadkowa, funduszPracy, fgsp types.Number,
+	kosztyUzyskania, podatek types.Number,
+) types.PayrollParameters {
+	return types.PayrollParameters{
+		PensionEmployee:    emerytalnaPracownik,
+		DisabilityEmployee: rentowaPracownik,
+		Sickness:           chorobowa,
+		Health:             zdrowotna,
+		PensionEmployer:    emerytalnaPlatnik,
+		DisabilityEmployer: rentowaPlatnik,
+		Accident:           wypadkowa,
+		LabourFund:         funduszPracy,
+		GuaranteeFund:      fgsp,
+		TaxDeductibleCosts: kosztyUzyskania,
+		Tax:                podatek,
+	}
+}
+
+// Ubezpieczenia definiuje ubezpieczenia, którym podlega umowa.
+func Ubezpieczenia(spoleczne, chorobowe, zdrowotne, funduszPracy bool) types.Insurance {
+	return types.Insurance{
+		Social:     spoleczne,
+		Sickness:   chorobowe,
+		Health:     zdrowotne,
+		LabourFund: funduszPracy,
+	}
+}
+
+// BezUbezpieczen oznacza umowę niepodlegającą ubezpieczeniom.
+func BezUbezpieczen() types.Insurance {
+	return types.Insurance{}
+}
+
+// Wykonawca definiuje osobę fizyczną, z którą zawarto umowę cywilnoprawną.
+func Wykonawca(imie, nazwisko, pesel string, adres types.Address) types.Employee {
+	lo.Must0(types.ValidatePESEL(pesel))
+	return types.Employee{
+		FirstName: imie,
+		LastName:  nazwisko,
+		PESEL:     pesel,
+		Address:   adres,
+	}
+}
+
+// AdresZamieszkania definiuje adres zamieszkania w Polsce wymagany w deklaracjach podatkowych.
+func AdresZamieszkania(
+	wojewodztwo, powiat, gmina, ulica, nrDomu, nrLokalu, miejscowosc, kodPocztowy string,
+) types.Address {
+	return types.Address{
+		CountryCode:  "PL",
+		Province:     wojewodztwo,
+		County:       powiat,
+		Municipality: gmina,
+		Street:       ulica,
+		HouseNumber:  nrDomu,
+		FlatNumber:   nrLokalu,
+		City:         miejscowosc,
+		PostalCode:   kodPocztowy,
+	}
+}
+
+// Umowa definiuje rachunek do umowy cywilnoprawnej, od którego pobierane są zaliczka na podatek i składki.
+func Umowa(
+	data time.Time,
+	dokument types.Document,
+	wykonawca types.Employee,
+	rodzaj types.ContractType,
+	kwotaBrutto types.Denom,
+	ubezpieczenia types.Insurance,
+	parametry types.PayrollParameters,
+	typPodatkowy types.CostTaxType,
+	typPozytku types.CostCategoryType,
+	platnosci []types.Payment,
+	opis string,
+) []types.Operation {
+	return []types.Operation{&operations.Contract{
+		Date:             data,
+		Document:         dokument,
+		Employee:         wykonawca,
+		Type:             rodzaj,
+		Amount:           kwotaBrutto,
+		Insurance:        ubezpieczenia,
+		Parameters:       parametry,
+		CostTaxType:      typPodatkowy,
+		CostCategoryType: typPozytku,
+		Payments:         platnosci,
 		Notes:            opis,
 	}}
 }
 
+// ZaplataPIT definiuje przelew zaliczek na podatek pobranych od wynagrodzeń.
+func ZaplataPIT(
+	data time.Time,
+	dokument types.Document,
+	urzad types.Contractor,
+	platnosci []types.Payment,
+	opis string,
+) []types.Operation {
+	return []types.Operation{&operations.PayrollPayment{
+		Date:       data,
+		Document:   dokument,
+		Contractor: urzad,
+		Payee:      types.PayrollPayeeTaxOffice,
+		Payments:   platnosci,
+		Notes:      opis,
+	}}
+}
+
+// ZaplataZUS definiuje przelew składek na ubezpieczenia społeczne i zdrowotne.
+func ZaplataZUS(
+	data time.Time,
+	dokument types.Document,
+	zus types.Contractor,
+	platnosci []types.Payment,
+	opis string,
+) []types.Operation {
+	return []types.Operation{&operations.PayrollPayment{
+		Date:       data,
+		Document:   dokument,
+		Contractor: zus,
+		Payee:      types.PayrollPayeeSocialInsurance,
+		Payments:   platnosci,
+		Notes:      opis,
+	}}
+}
+
 // Raport generuje raport.
 func Raport(
 	naDzien time.Time,
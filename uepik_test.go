@@ -0,0 +1,92 @@
+package uepik
+
+import (
+	"testing"
+
+	"github.com/outofforest/uepik/v2/types"
+	"github.com/outofforest/uepik/v2/types/operations"
+)
+
+func TestInvoiceNumbering(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		documents []types.Document
+		expected  []types.DocumentID
+	}{
+		{
+			name: "numbered by date",
+			documents: []types.Document{
+				Faktura("FS", Data(2025, 3, 10)),
+				Faktura("FS", Data(2025, 1, 5)),
+				Faktura("FS", Data(2025, 2, 1)),
+			},
+			expected: []types.DocumentID{"FS/3/2025", "FS/1/2025", "FS/2/2025"},
+		},
+		{
+			name: "same date keeps definition order",
+			documents: []types.Document{
+				Faktura("FS", Data(2025, 1, 5)),
+				Faktura("FS", Data(2025, 1, 5)),
+			},
+			expected: []types.DocumentID{"FS/1/2025", "FS/2/2025"},
+		},
+		{
+			name: "separate series",
+			documents: []types.Document{
+				Faktura("FS", Data(2025, 1, 5)),
+				Faktura("FE", Data(2025, 1, 6)),
+				Faktura("FS", Data(2025, 1, 7)),
+			},
+			expected: []types.DocumentID{"FS/1/2025", "FE/1/2025", "FS/2/2025"},
+		},
+		{
+			name: "separate years",
+			documents: []types.Document{
+				Faktura("FS", Data(2025, 12, 31)),
+				Faktura("FS", Data(2026, 1, 1)),
+				Faktura("FS", Data(2025, 12, 30)),
+			},
+			expected: []types.DocumentID{"FS/2/2025", "FS/1/2026", "FS/1/2025"},
+		},
+		{
+			name: "documents without series are not numbered",
+			documents: []types.Document{
+				Dokument("FV/7/2025", Data(2025, 1, 4)),
+				Faktura("FS", Data(2025, 1, 5)),
+			},
+			expected: []types.DocumentID{"FV/7/2025", "FS/1/2025"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ops := make([]types.Operation, 0, len(tt.documents))
+			for _, d := range tt.documents {
+				ops = append(ops, &operations.Sell{Date: d.Date, Document: d})
+			}
+			rok := Rok("", "", "", Data(2025, 1, 1), Data(2025, 12, 31), types.Init{}, ops)
+
+			for i, op := range rok.Operations {
+				if id := op.(*operations.Sell).Document.ID; id != tt.expected[i] {
+					t.Errorf("operation %d: expected %s, got %s", i, tt.expected[i], id)
+				}
+			}
+		})
+	}
+}
+
+func TestInvoiceNumberingRestartsForEachYearDefinition(t *testing.T) {
+	t.Parallel()
+
+	for range 2 {
+		sell := &operations.Sell{Date: Data(2025, 1, 5), Document: Faktura("FS", Data(2025, 1, 5))}
+		rok := Rok("", "", "", Data(2025, 1, 1), Data(2025, 12, 31), types.Init{}, []types.Operation{sell})
+		if id := rok.Operations[0].(*operations.Sell).Document.ID; id != "FS/1/2025" {
+			t.Fatalf("expected FS/1/2025, got %s", id)
+		}
+	}
+}